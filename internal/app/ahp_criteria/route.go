@@ -0,0 +1,16 @@
+package ahpcriteria
+
+import (
+	"bm_binus/internal/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Route(v *echo.Group) {
+	v.GET("", h.Find, middleware.Authentication)
+	v.POST("", h.Create, middleware.Authentication)
+	v.GET("/:id", h.FindById, middleware.Authentication)
+	v.PUT("/:id", h.Update, middleware.Authentication)
+	v.PATCH("/:id/activate", h.Activate, middleware.Authentication)
+	v.DELETE("/:id", h.Delete, middleware.Authentication)
+}
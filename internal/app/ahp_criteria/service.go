@@ -0,0 +1,356 @@
+package ahpcriteria
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Find(ctx *abstraction.Context) (map[string]interface{}, error)
+	FindById(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileFindByIDRequest) (map[string]interface{}, error)
+	Create(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileCreateRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileUpdateRequest) (map[string]interface{}, error)
+	Activate(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileActivateRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileDeleteByIDRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	AhpCriteriaProfileRepository repository.AhpCriteriaProfile
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		AhpCriteriaProfileRepository: f.AhpCriteriaProfileRepository,
+
+		DB: f.Db,
+	}
+}
+
+// VersionResponse: bobot tiap kriteria beserta CR dari satu versi profil
+func VersionResponse(v *model.AhpCriteriaProfileVersionEntityModel) map[string]interface{} {
+	var (
+		weights    []float64
+		matrix     [][]float64
+		comparison []dto.AhpComparisonRequest
+		hints      []map[string]interface{} = nil
+	)
+	_ = json.Unmarshal([]byte(v.Weights), &weights)
+	_ = json.Unmarshal([]byte(v.Matrix), &matrix)
+	_ = json.Unmarshal([]byte(v.Comparison), &comparison)
+
+	// saran revisi hanya untuk versi yang tidak konsisten
+	if v.ConsistencyRatio > constant.AHP_CR_THRESHOLD && len(matrix) == len(weights) {
+		hints = general.InconsistencyHints(constant.AHP_CRITERIA, matrix, weights, constant.AHP_HINT_LIMIT)
+	}
+
+	weightRes := map[string]interface{}{}
+	for i, name := range constant.AHP_CRITERIA {
+		if i < len(weights) {
+			weightRes[name] = math.Round(weights[i]*10000) / 10000
+		}
+	}
+	return map[string]interface{}{
+		"version":           v.Version,
+		"comparison":        comparison,
+		"weights":           weightRes,
+		"consistency_ratio": math.Round(v.ConsistencyRatio*10000) / 10000,
+		"is_consistent":     v.ConsistencyRatio <= constant.AHP_CR_THRESHOLD,
+		"hints":             hints,
+		"created_at":        general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		"created_by": map[string]interface{}{
+			"id":   v.CreateBy.ID,
+			"name": v.CreateBy.Name,
+		},
+	}
+}
+
+func checkRole(ctx *abstraction.Context) error {
+	if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+	}
+	return nil
+}
+
+// findOwnProfile: profil hanya bisa diakses oleh BM pembuatnya
+func findOwnProfile(s *service, ctx *abstraction.Context, id int) (*model.AhpCriteriaProfileEntityModel, error) {
+	profileData, err := s.AhpCriteriaProfileRepository.FindById(ctx, id)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if profileData == nil || profileData.UserId != ctx.Auth.ID {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "ahp criteria profile not found")
+	}
+	return profileData, nil
+}
+
+// createVersion memvalidasi perbandingan kriteria lalu menyimpan matriks, bobot dan CR-nya sebagai versi baru
+func createVersion(s *service, ctx *abstraction.Context, profileId int, version int, comparison []dto.AhpComparisonRequest) error {
+	var comps []general.AhpComparison
+	for _, v := range comparison {
+		comps = append(comps, general.AhpComparison{Item1: strings.TrimSpace(v.Item1), Item2: strings.TrimSpace(v.Item2), Value: v.Value})
+	}
+	if problems := general.ValidatePairwiseComparisons(constant.AHP_CRITERIA, comps); len(problems) > 0 {
+		return response.ErrorBuilderWithData(
+			http.StatusBadRequest,
+			errors.New("invalid_comparison"),
+			"Perbandingan kriteria tidak valid",
+			map[string]interface{}{
+				"criteria": constant.AHP_CRITERIA,
+				"problems": problems,
+			},
+		)
+	}
+
+	// metode ranking dipilih per request (ahp_method), jadi CR yang disimpan adalah yang terbesar dari kedua metode
+	// supaya profil yang lolos batas konsistensi tetap konsisten dengan metode apa pun
+	matrix := general.BuildPairwiseFromComparisons(constant.AHP_CRITERIA, comps)
+	approximate := general.CalculateAHPWithMethod(matrix, constant.AHP_METHOD_APPROXIMATE)
+	eigenvector := general.CalculateAHPWithMethod(matrix, constant.AHP_METHOD_EIGENVECTOR)
+	weights, cr := approximate.Weights, math.Max(approximate.CR, eigenvector.CR)
+
+	comparisonJSON, _ := json.Marshal(comparison)
+	matrixJSON, _ := json.Marshal(matrix)
+	weightsJSON, _ := json.Marshal(weights)
+	modelVersion := &model.AhpCriteriaProfileVersionEntityModel{
+		Context: ctx,
+		AhpCriteriaProfileVersionEntity: model.AhpCriteriaProfileVersionEntity{
+			ProfileId:        profileId,
+			Version:          version,
+			Comparison:       string(comparisonJSON),
+			Matrix:           string(matrixJSON),
+			Weights:          string(weightsJSON),
+			ConsistencyRatio: cr,
+		},
+	}
+	if err := s.AhpCriteriaProfileRepository.CreateVersion(ctx, modelVersion).Error; err != nil {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	return nil
+}
+
+func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error) {
+	if err := checkRole(ctx); err != nil {
+		return nil, err
+	}
+
+	var res []map[string]interface{} = nil
+	data, err := s.AhpCriteriaProfileRepository.FindByUserId(ctx, ctx.Auth.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var latestRes map[string]interface{} = nil
+		versionData, err := s.AhpCriteriaProfileRepository.FindVersion(ctx, v.ID, v.LatestVersion)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if versionData != nil {
+			latestRes = VersionResponse(versionData)
+		}
+		res = append(res, map[string]interface{}{
+			"id":             v.ID,
+			"name":           v.Name,
+			"latest_version": v.LatestVersion,
+			"is_active":      v.IsActive,
+			"latest":         latestRes,
+			"created_at":     general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		})
+	}
+
+	return map[string]interface{}{
+		"count":    len(res),
+		"criteria": constant.AHP_CRITERIA,
+		"data":     res,
+	}, nil
+}
+
+func (s *service) FindById(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileFindByIDRequest) (map[string]interface{}, error) {
+	if err := checkRole(ctx); err != nil {
+		return nil, err
+	}
+	profileData, err := findOwnProfile(s, ctx, payload.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var versionRes []map[string]interface{} = nil
+	versionData, err := s.AhpCriteriaProfileRepository.FindVersions(ctx, profileData.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range versionData {
+		versionRes = append(versionRes, VersionResponse(v))
+	}
+
+	return map[string]interface{}{
+		"data": map[string]interface{}{
+			"id":             profileData.ID,
+			"name":           profileData.Name,
+			"latest_version": profileData.LatestVersion,
+			"is_active":      profileData.IsActive,
+			"criteria":       constant.AHP_CRITERIA,
+			"versions":       versionRes,
+			"created_at":     general.FormatWithZWithoutChangingTime(profileData.CreatedAt),
+		},
+	}, nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileCreateRequest) (map[string]interface{}, error) {
+	var resId int
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if err := checkRole(ctx); err != nil {
+			return err
+		}
+
+		modelProfile := &model.AhpCriteriaProfileEntityModel{
+			Context: ctx,
+			AhpCriteriaProfileEntity: model.AhpCriteriaProfileEntity{
+				UserId:        ctx.Auth.ID,
+				Name:          strings.TrimSpace(payload.Name),
+				LatestVersion: 1,
+				IsActive:      false,
+				IsDelete:      false,
+			},
+		}
+		if err := s.AhpCriteriaProfileRepository.Create(ctx, modelProfile).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if err := createVersion(s, ctx, modelProfile.ID, 1, payload.Comparison); err != nil {
+			return err
+		}
+
+		resId = modelProfile.ID
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return s.FindById(ctx, &dto.AhpCriteriaProfileFindByIDRequest{ID: resId})
+}
+
+// Update mengganti nama profil, perbandingan baru disimpan sebagai versi berikutnya tanpa mengubah versi lama
+func (s *service) Update(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileUpdateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if err := checkRole(ctx); err != nil {
+			return err
+		}
+		profileData, err := findOwnProfile(s, ctx, payload.ID)
+		if err != nil {
+			return err
+		}
+
+		newProfileData := new(model.AhpCriteriaProfileEntityModel)
+		newProfileData.Context = ctx
+		newProfileData.ID = payload.ID
+		if payload.Name != nil {
+			if strings.TrimSpace(*payload.Name) == "" {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "name is required")
+			}
+			newProfileData.Name = strings.TrimSpace(*payload.Name)
+		}
+		if payload.Comparison != nil {
+			newVersion := profileData.LatestVersion + 1
+			if err = createVersion(s, ctx, profileData.ID, newVersion, payload.Comparison); err != nil {
+				return err
+			}
+			newProfileData.LatestVersion = newVersion
+
+			// profil aktif yang versi barunya tidak konsisten dinonaktifkan supaya ranking kembali ke default
+			versionData, err := s.AhpCriteriaProfileRepository.FindVersion(ctx, profileData.ID, newVersion)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if profileData.IsActive && versionData.ConsistencyRatio > constant.AHP_CR_THRESHOLD {
+				if err = s.AhpCriteriaProfileRepository.UpdateColumns(ctx, profileData.ID, map[string]interface{}{"is_active": false}).Error; err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+			}
+		}
+
+		if err = s.AhpCriteriaProfileRepository.Update(ctx, newProfileData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return s.FindById(ctx, &dto.AhpCriteriaProfileFindByIDRequest{ID: payload.ID})
+}
+
+// Activate menjadikan profil sebagai dasar ranking AHP milik BM, hanya versi terakhir yang konsisten (CR <= 0.1) yang boleh dipakai
+func (s *service) Activate(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileActivateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if err := checkRole(ctx); err != nil {
+			return err
+		}
+		profileData, err := findOwnProfile(s, ctx, payload.ID)
+		if err != nil {
+			return err
+		}
+
+		versionData, err := s.AhpCriteriaProfileRepository.FindVersion(ctx, profileData.ID, profileData.LatestVersion)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if versionData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "ahp criteria profile has no version")
+		}
+		if versionData.ConsistencyRatio > constant.AHP_CR_THRESHOLD {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "consistency ratio of the latest version exceeds 0.1, revise the comparison first")
+		}
+
+		if err = s.AhpCriteriaProfileRepository.DeactivateByUserId(ctx, ctx.Auth.ID).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if err = s.AhpCriteriaProfileRepository.UpdateColumns(ctx, profileData.ID, map[string]interface{}{"is_active": true}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success activate!",
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.AhpCriteriaProfileDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if err := checkRole(ctx); err != nil {
+			return err
+		}
+		profileData, err := findOwnProfile(s, ctx, payload.ID)
+		if err != nil {
+			return err
+		}
+
+		if err = s.AhpCriteriaProfileRepository.UpdateColumns(ctx, profileData.ID, map[string]interface{}{
+			"is_delete":  true,
+			"is_active":  false,
+			"updated_at": general.NowLocal(),
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
@@ -44,8 +44,120 @@ func NewService(f *factory.Factory) Service {
 	}
 }
 
+// consistencyHints: saran revisi perbandingan yang paling tidak konsisten, hanya jika CR melebihi 0.1
+func consistencyHints(items []string, matrix [][]float64, result general.AhpResult) []map[string]interface{} {
+	if result.CR <= constant.AHP_CR_THRESHOLD {
+		return nil
+	}
+	return general.InconsistencyHints(items, matrix, result.Weights, constant.AHP_HINT_LIMIT)
+}
+
+func toAhpComparisons(comps []dto.AhpComparisonRequest) []general.AhpComparison {
+	var res []general.AhpComparison
+	for _, v := range comps {
+		res = append(res, general.AhpComparison{Item1: v.Item1, Item2: v.Item2, Value: v.Value})
+	}
+	return res
+}
+
+// nameProblems: nama kriteria/alternatif harus unik, tidak kosong dan tanpa koma (disimpan digabung dengan koma)
+func nameProblems(section string, items []string) []map[string]interface{} {
+	var problems []map[string]interface{}
+	seen := map[string]bool{}
+	for _, v := range items {
+		if strings.TrimSpace(v) == "" {
+			problems = append(problems, map[string]interface{}{
+				"section": section,
+				"type":    "empty_item",
+				"item1":   v,
+				"message": "nama tidak boleh kosong",
+			})
+			continue
+		}
+		if strings.Contains(v, ",") {
+			problems = append(problems, map[string]interface{}{
+				"section": section,
+				"type":    "comma_in_name",
+				"item1":   v,
+				"message": "nama tidak boleh mengandung koma",
+			})
+		}
+		if seen[v] {
+			problems = append(problems, map[string]interface{}{
+				"section": section,
+				"type":    "duplicate_item",
+				"item1":   v,
+				"message": "nama sudah dipakai",
+			})
+		}
+		seen[v] = true
+	}
+	return problems
+}
+
+// validatePayload memeriksa seluruh perbandingan sebelum dihitung: nama yang tidak terdaftar, nilai di luar skala
+// Saaty 1/9-9 (termasuk 0), pasangan yang dinilai tidak resiprokal, pasangan yang belum dinilai, serta kriteria
+// pada alternatif_comparison yang tidak ada atau terlewat
+func validatePayload(payload *dto.AhpHistoryCreateRequest) []map[string]interface{} {
+	problems := append(nameProblems("kriteria", payload.Kriteria), nameProblems("alternatif", payload.Alternatif)...)
+	if len(problems) > 0 {
+		return problems
+	}
+
+	for _, v := range general.ValidatePairwiseComparisons(payload.Kriteria, toAhpComparisons(payload.KriteriaComparison)) {
+		v["section"] = "kriteria_comparison"
+		problems = append(problems, v)
+	}
+
+	for _, kriteriaName := range payload.Kriteria {
+		comps, ok := payload.AlternatifComparison[kriteriaName]
+		if !ok {
+			problems = append(problems, map[string]interface{}{
+				"section":  "alternatif_comparison",
+				"type":     "missing_kriteria",
+				"kriteria": kriteriaName,
+				"message":  "perbandingan alternatif untuk kriteria ini belum diisi",
+			})
+			continue
+		}
+		for _, v := range general.ValidatePairwiseComparisons(payload.Alternatif, toAhpComparisons(comps)) {
+			v["section"] = "alternatif_comparison"
+			v["kriteria"] = kriteriaName
+			problems = append(problems, v)
+		}
+	}
+
+	var unknown []string
+	for kriteriaName := range payload.AlternatifComparison {
+		if !slices.Contains(payload.Kriteria, kriteriaName) {
+			unknown = append(unknown, kriteriaName)
+		}
+	}
+	sort.Strings(unknown)
+	for _, kriteriaName := range unknown {
+		problems = append(problems, map[string]interface{}{
+			"section":  "alternatif_comparison",
+			"type":     "unknown_kriteria",
+			"kriteria": kriteriaName,
+			"message":  "kriteria tidak terdaftar",
+		})
+	}
+	return problems
+}
+
+// historyMethod: riwayat lama sebelum ada pilihan method dihitung dengan approximate
+func historyMethod(method string) string {
+	if method == "" {
+		return constant.AHP_METHOD_APPROXIMATE
+	}
+	return method
+}
+
 func (s *service) Create(ctx *abstraction.Context, payload *dto.AhpHistoryCreateRequest) (map[string]interface{}, error) {
-	var resId int
+	var (
+		resId          int
+		consistencyRes = map[string]interface{}{}
+	)
 	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
 		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
 			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
@@ -59,93 +171,80 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.AhpHistoryCreate
 			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
 		}
 
-		fmt.Println("=== [AHP DYNAMIC CALCULATION] ===")
-
-		// --- [ Tambahan baru: konversi payload comparison ke matrix input ] ---
-
-		// fungsi bantu: bangun matriks pairwise dari AhpComparisonRequest (kriteria_comparison)
-		buildPairwiseFromComparison := func(items []string, comps []dto.AhpComparisonRequest) [][]float64 {
-			n := len(items)
-			matrix := make([][]float64, n)
-			for i := range matrix {
-				matrix[i] = make([]float64, n)
-				for j := range matrix[i] {
-					matrix[i][j] = 1
-				}
-			}
-			for _, c := range comps {
-				i1 := slices.Index(items, c.Item1)
-				i2 := slices.Index(items, c.Item2)
-				if i1 == -1 || i2 == -1 {
-					continue
-				}
-				matrix[i1][i2] = c.Value
-				matrix[i2][i1] = 1 / c.Value
+		method := constant.AHP_METHOD_APPROXIMATE
+		if payload.Method != nil && *payload.Method != "" {
+			if !general.IsValidAhpMethod(*payload.Method) {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "method must be approximate or eigenvector")
 			}
-			return matrix
+			method = *payload.Method
 		}
 
-		// fungsi bantu: ambil matrix alternatif per kriteria dari map
-		buildAltMatrixFromMap := func(alternatif []string, comps []dto.AhpComparisonRequest) [][]float64 {
-			n := len(alternatif)
-			matrix := make([][]float64, n)
-			for i := range matrix {
-				matrix[i] = make([]float64, n)
-				for j := range matrix[i] {
-					matrix[i][j] = 1
-				}
-			}
-			for _, c := range comps {
-				i1 := slices.Index(alternatif, c.Item1)
-				i2 := slices.Index(alternatif, c.Item2)
-				if i1 == -1 || i2 == -1 {
-					continue
-				}
-				matrix[i1][i2] = c.Value
-				matrix[i2][i1] = 1 / c.Value
-			}
-			return matrix
+		if problems := validatePayload(payload); len(problems) > 0 {
+			return response.ErrorBuilderWithData(
+				http.StatusBadRequest,
+				errors.New("invalid_comparison"),
+				"Perbandingan AHP tidak valid",
+				map[string]interface{}{
+					"problems": problems,
+				},
+			)
 		}
 
+		fmt.Println("=== [AHP DYNAMIC CALCULATION] ===")
+		fmt.Println("Method:", method)
+
 		// --- 1. Bangun pairwise matrix Kriteria ---
-		kritMatrix := buildPairwiseFromComparison(payload.Kriteria, payload.KriteriaComparison)
+		kritMatrix := general.BuildPairwiseFromComparisons(payload.Kriteria, toAhpComparisons(payload.KriteriaComparison))
 
 		fmt.Println("\n[ Matriks Kriteria ]")
 		general.PrintMatrix(kritMatrix)
 
-		kritWeights, kritCR := general.CalculateAHP(kritMatrix)
+		kritResult := general.CalculateAHPWithMethod(kritMatrix, method)
+		kritWeights := kritResult.Weights
 		fmt.Println("Bobot Kriteria:", kritWeights)
-		fmt.Printf("CR: %.4f\n", kritCR)
+		fmt.Printf("Lambda Max: %.4f\n", kritResult.LambdaMax)
+		fmt.Printf("CR: %.4f\n", kritResult.CR)
 
 		// --- 2. Bangun pairwise matrix untuk setiap kriteria terhadap alternatif ---
+		// validatePayload sudah memastikan setiap kriteria punya perbandingan alternatif dan tidak ada kriteria asing
 		nAlt := len(payload.Alternatif)
 		totalScore := make([]float64, nAlt)
+		storedAlternatifComparison := map[string]interface{}{}
+		altConsistencyRes := map[string]interface{}{}
 
-		for kriteriaName, comps := range payload.AlternatifComparison {
+		for kIndex, kriteriaName := range payload.Kriteria {
 			fmt.Printf("\n[ Matriks Alternatif terhadap %s ]\n", kriteriaName)
 
-			mAlt := buildAltMatrixFromMap(payload.Alternatif, comps)
+			mAlt := general.BuildPairwiseFromComparisons(payload.Alternatif, toAhpComparisons(payload.AlternatifComparison[kriteriaName]))
 			general.PrintMatrix(mAlt)
-			wAlt, crAlt := general.CalculateAHP(mAlt)
+			altResult := general.CalculateAHPWithMethod(mAlt, method)
+			wAlt := altResult.Weights
 			fmt.Println("Bobot alternatif:", wAlt)
-			fmt.Printf("CR: %.4f\n", crAlt)
-
-			// cari index kriteria yang sesuai
-			kIndex := -1
-			for i, k := range payload.Kriteria {
-				if k == kriteriaName {
-					kIndex = i
-					break
-				}
-			}
-			if kIndex == -1 {
-				continue
-			}
+			fmt.Printf("Lambda Max: %.4f\n", altResult.LambdaMax)
+			fmt.Printf("CR: %.4f\n", altResult.CR)
 
 			// akumulasi skor global
 			for i := 0; i < nAlt; i++ {
 				totalScore[i] += kritWeights[kIndex] * wAlt[i]
 			}
+
+			altHints := consistencyHints(payload.Alternatif, mAlt, altResult)
+			storedAlternatifComparison[kriteriaName] = map[string]interface{}{
+				"matrix":        mAlt,
+				"weights":       wAlt,
+				"lambda_max":    altResult.LambdaMax,
+				"ci":            altResult.CI,
+				"cr":            altResult.CR,
+				"iterations":    altResult.Iterations,
+				"converged":     altResult.Converged,
+				"is_consistent": altResult.CR <= constant.AHP_CR_THRESHOLD,
+				"hints":         altHints,
+			}
+			altConsistencyRes[kriteriaName] = map[string]interface{}{
+				"cr":            altResult.CR,
+				"is_consistent": altResult.CR <= constant.AHP_CR_THRESHOLD,
+				"hints":         altHints,
+			}
 		}
 
 		// --- 3. Ranking hasil akhir ---
@@ -168,22 +267,24 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.AhpHistoryCreate
 
 		// prepare data for save to db
 		storedKriteriaComparison := map[string]interface{}{
-			"matrix":  kritMatrix,
-			"weights": kritWeights,
-			"cr":      kritCR,
+			"matrix":        kritMatrix,
+			"weights":       kritWeights,
+			"lambda_max":    kritResult.LambdaMax,
+			"ci":            kritResult.CI,
+			"cr":            kritResult.CR,
+			"iterations":    kritResult.Iterations,
+			"converged":     kritResult.Converged,
+			"is_consistent": kritResult.CR <= constant.AHP_CR_THRESHOLD,
+			"hints":         consistencyHints(payload.Kriteria, kritMatrix, kritResult),
 		}
-
-		storedAlternatifComparison := map[string]interface{}{}
-		for kriteriaName, comps := range payload.AlternatifComparison {
-			mAlt := buildAltMatrixFromMap(payload.Alternatif, comps)
-			wAlt, crAlt := general.CalculateAHP(mAlt)
-			storedAlternatifComparison[kriteriaName] = map[string]interface{}{
-				"matrix":  mAlt,
-				"weights": wAlt,
-				"cr":      crAlt,
-			}
+		consistencyRes["kriteria"] = map[string]interface{}{
+			"cr":            kritResult.CR,
+			"is_consistent": storedKriteriaComparison["is_consistent"],
+			"hints":         storedKriteriaComparison["hints"],
 		}
 
+		consistencyRes["alternatif"] = altConsistencyRes
+
 		storedPriorityGlobal := map[string]interface{}{
 			"alternatif": payload.Alternatif,
 			"priority":   results, // hasil total skor (global priority)
@@ -203,6 +304,7 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.AhpHistoryCreate
 				AlternatifComparison: string(altJSON),
 				PriorityGlobal:       string(prioJSON),
 				ReferenceRequest:     payload.ReferenceRequest,
+				Method:               method,
 				IsDelete:             false,
 			},
 		}
@@ -217,8 +319,9 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.AhpHistoryCreate
 	}
 
 	return map[string]interface{}{
-		"message": "success create!",
-		"id":      resId,
+		"message":     "success create!",
+		"id":          resId,
+		"consistency": consistencyRes,
 	}, nil
 }
 
@@ -269,6 +372,7 @@ func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error)
 			"kriteria":   kriteriaVal,
 			"alternatif": alternatifVal,
 			"priority":   globalSummary,
+			"method":     historyMethod(v.Method),
 			"reference_request": map[string]interface{}{
 				"id":         requestData.ID,
 				"user":       requestData.User.Name,
@@ -316,9 +420,12 @@ func (s *service) FindById(ctx *abstraction.Context, payload *dto.AhpHistoryFind
 
 		// --- format ringkasan kriteria ---
 		kritSummary := map[string]interface{}{
-			"total": len(kriteriaVal),
-			"list":  kriteriaVal,
-			"cr":    kriteriaData["cr"],
+			"total":         len(kriteriaVal),
+			"list":          kriteriaVal,
+			"lambda_max":    kriteriaData["lambda_max"],
+			"cr":            kriteriaData["cr"],
+			"is_consistent": kriteriaData["is_consistent"],
+			"hints":         kriteriaData["hints"],
 			"weights": func() []map[string]interface{} {
 				ws := []map[string]interface{}{}
 				if arr, ok := kriteriaData["weights"].([]interface{}); ok {
@@ -339,10 +446,13 @@ func (s *service) FindById(ctx *abstraction.Context, payload *dto.AhpHistoryFind
 		for k, v2 := range altData {
 			if m, ok := v2.(map[string]interface{}); ok {
 				altSummary = append(altSummary, map[string]interface{}{
-					"kriteria": k,
-					"cr":       m["cr"],
-					"weights":  m["weights"],
-					"matrix":   m["matrix"],
+					"kriteria":      k,
+					"lambda_max":    m["lambda_max"],
+					"cr":            m["cr"],
+					"is_consistent": m["is_consistent"],
+					"hints":         m["hints"],
+					"weights":       m["weights"],
+					"matrix":        m["matrix"],
 				})
 			}
 		}
@@ -364,6 +474,7 @@ func (s *service) FindById(ctx *abstraction.Context, payload *dto.AhpHistoryFind
 		// --- build final response ---
 		res = map[string]interface{}{
 			"id":                 data.ID,
+			"method":             historyMethod(data.Method),
 			"kriteria_summary":   kritSummary,
 			"alternatif_summary": altSummary,
 			"global_priority":    globalSummary,
@@ -0,0 +1,306 @@
+package asset
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"errors"
+	"net/http"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Find(ctx *abstraction.Context) (map[string]interface{}, error)
+	FindById(ctx *abstraction.Context, payload *dto.AssetFindByIDRequest) (map[string]interface{}, error)
+	Create(ctx *abstraction.Context, payload *dto.AssetCreateRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.AssetUpdateRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.AssetDeleteByIDRequest) (map[string]interface{}, error)
+	Availability(ctx *abstraction.Context, payload *dto.AssetAvailabilityRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	AssetRepository        repository.Asset
+	LocationRepository     repository.Location
+	RequestAssetRepository repository.RequestAsset
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		AssetRepository:        f.AssetRepository,
+		LocationRepository:     f.LocationRepository,
+		RequestAssetRepository: f.RequestAssetRepository,
+
+		DB: f.Db,
+	}
+}
+
+func assetResponse(v *model.AssetEntityModel) map[string]interface{} {
+	var locationRes map[string]interface{} = nil
+	if v.Location != nil {
+		locationRes = map[string]interface{}{
+			"id":   v.Location.ID,
+			"name": v.Location.Name,
+		}
+	}
+	return map[string]interface{}{
+		"id":         v.ID,
+		"name":       v.Name,
+		"item_type":  v.ItemType,
+		"quantity":   v.Quantity,
+		"location":   locationRes,
+		"is_active":  v.IsActive,
+		"created_at": v.CreatedAt,
+		"updated_at": v.UpdatedAt,
+	}
+}
+
+func findLocation(s *service, ctx *abstraction.Context, locationId int) error {
+	locationData, err := s.LocationRepository.FindById(ctx, locationId)
+	if err != nil && err.Error() != "record not found" {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if locationData == nil {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+	}
+	return nil
+}
+
+func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	data, err := s.AssetRepository.Find(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.AssetRepository.Count(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		res = append(res, assetResponse(v))
+	}
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+func (s *service) FindById(ctx *abstraction.Context, payload *dto.AssetFindByIDRequest) (map[string]interface{}, error) {
+	data, err := s.AssetRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if data == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "asset not found")
+	}
+	return map[string]interface{}{
+		"data": assetResponse(data),
+	}, nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.AssetCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		existData, err := s.AssetRepository.FindByName(ctx, payload.Name)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if existData != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "asset name already exists")
+		}
+
+		if payload.LocationId != nil {
+			if err = findLocation(s, ctx, *payload.LocationId); err != nil {
+				return err
+			}
+		}
+
+		isActive := true
+		if payload.IsActive != nil {
+			isActive = *payload.IsActive
+		}
+		modelAsset := &model.AssetEntityModel{
+			Context: ctx,
+			AssetEntity: model.AssetEntity{
+				Name:       strings.TrimSpace(payload.Name),
+				ItemType:   strings.TrimSpace(payload.ItemType),
+				Quantity:   payload.Quantity,
+				LocationId: payload.LocationId,
+				IsActive:   isActive,
+				IsDelete:   false,
+			},
+		}
+		if err = s.AssetRepository.Create(ctx, modelAsset).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) Update(ctx *abstraction.Context, payload *dto.AssetUpdateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		assetData, err := s.AssetRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if assetData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "asset not found")
+		}
+
+		newAssetData := new(model.AssetEntityModel)
+		newAssetData.Context = ctx
+		newAssetData.ID = payload.ID
+		if payload.Name != nil {
+			existData, err := s.AssetRepository.FindByName(ctx, *payload.Name)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if existData != nil && existData.ID != payload.ID {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "asset name already exists")
+			}
+			newAssetData.Name = strings.TrimSpace(*payload.Name)
+		}
+		if payload.ItemType != nil {
+			newAssetData.ItemType = strings.TrimSpace(*payload.ItemType)
+		}
+		if payload.LocationId != nil {
+			if err = findLocation(s, ctx, *payload.LocationId); err != nil {
+				return err
+			}
+			newAssetData.LocationId = payload.LocationId
+		}
+
+		if err = s.AssetRepository.Update(ctx, newAssetData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		// nilai 0/false tidak ikut terupdate lewat struct, jadi diset eksplisit
+		columns := map[string]interface{}{}
+		if payload.Quantity != nil {
+			columns["quantity"] = *payload.Quantity
+		}
+		if payload.IsActive != nil {
+			columns["is_active"] = *payload.IsActive
+		}
+		if len(columns) > 0 {
+			if err = s.AssetRepository.UpdateColumns(ctx, payload.ID, columns).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.AssetDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		assetData, err := s.AssetRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if assetData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "asset not found")
+		}
+
+		newAssetData := new(model.AssetEntityModel)
+		newAssetData.Context = ctx
+		newAssetData.ID = assetData.ID
+		newAssetData.IsDelete = true
+
+		if err = s.AssetRepository.Update(ctx, newAssetData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
+
+// Availability menghitung sisa stok tiap aset pada rentang tanggal, hanya pesanan dari request yang sudah disetujui yang mengurangi stok
+func (s *service) Availability(ctx *abstraction.Context, payload *dto.AssetAvailabilityRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	parsedDateStart, err := general.Parse("2006-01-02 15:04:05", payload.DateStart)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse date start:"+err.Error())
+	}
+	parsedDateEnd, err := general.Parse("2006-01-02 15:04:05", payload.DateEnd)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse date end:"+err.Error())
+	}
+	if !parsedDateStart.Before(parsedDateEnd) {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "Tanggal mulai harus lebih kecil dari tanggal selesai")
+	}
+
+	data, err := s.AssetRepository.Find(ctx, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	reserved, err := s.RequestAssetRepository.SumReserved(ctx, nil, parsedDateStart, parsedDateEnd, 0, constant.ASSET_BOOKED_STATUS_IDS)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	reservedMap := make(map[int]int)
+	for _, v := range reserved {
+		reservedMap[v.AssetID] = v.Total
+	}
+
+	for _, v := range data {
+		if !v.IsActive {
+			continue
+		}
+		available := v.Quantity - reservedMap[v.ID]
+		if available < 0 {
+			available = 0
+		}
+		res = append(res, map[string]interface{}{
+			"id":        v.ID,
+			"name":      v.Name,
+			"item_type": v.ItemType,
+			"quantity":  v.Quantity,
+			"reserved":  reservedMap[v.ID],
+			"available": available,
+		})
+	}
+
+	return map[string]interface{}{
+		"date_start": general.FormatWithZWithoutChangingTime(parsedDateStart),
+		"date_end":   general.FormatWithZWithoutChangingTime(parsedDateEnd),
+		"data":       res,
+	}, nil
+}
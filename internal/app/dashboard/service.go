@@ -4,6 +4,7 @@ import (
 	"bm_binus/internal/abstraction"
 	"bm_binus/internal/dto"
 	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
 	"bm_binus/internal/repository"
 	"bm_binus/pkg/constant"
 	"bm_binus/pkg/util/general"
@@ -14,6 +15,9 @@ import (
 	"gorm.io/gorm"
 )
 
+// budgetMonthCount: jumlah bulan terakhir (termasuk bulan ini) pada grafik anggaran per bulan
+const budgetMonthCount = 12
+
 type Service interface {
 	GetDashboard(ctx *abstraction.Context, payload *dto.GetDashboardRequest) (map[string]interface{}, error)
 }
@@ -68,6 +72,19 @@ func (s *service) GetDashboard(ctx *abstraction.Context, payload *dto.GetDashboa
 	if err != nil && err.Error() != "record not found" {
 		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 	}
+	countOverdue, err := s.DashboardRepository.CountOverdue(ctx, userId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	getBudgetByEventType, err := s.DashboardRepository.GetBudgetByEventType(ctx, userId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	budgetMonthStart := general.StartOfMonth(*general.NowWithLocation()).AddDate(0, -(budgetMonthCount - 1), 0)
+	getBudgetByMonth, err := s.DashboardRepository.GetBudgetByMonth(ctx, userId, budgetMonthStart)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
 	dataStatus, err := s.StatusRepository.Find(ctx, true)
 	if err != nil && err.Error() != "record not found" {
 		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
@@ -117,23 +134,72 @@ func (s *service) GetDashboard(ctx *abstraction.Context, payload *dto.GetDashboa
 		})
 	}
 
+	// =============================
+	// BUDGET HANDLER
+	// =============================
+	budgetEventTypeMap := make(map[int]*model.RequestBudgetByEventType)
+	for _, item := range getBudgetByEventType {
+		budgetEventTypeMap[item.EventTypeID] = item
+	}
+
+	var resBudgetByEventType []map[string]interface{}
+	for _, e := range dataEventType {
+		var estimated, actual int64
+		if val, ok := budgetEventTypeMap[e.ID]; ok {
+			estimated, actual = val.Estimated, val.Actual
+		}
+		resBudgetByEventType = append(resBudgetByEventType, map[string]interface{}{
+			"event_type": e.Name,
+			"estimated":  estimated,
+			"actual":     actual,
+		})
+	}
+
+	budgetMonthMap := make(map[string]*model.RequestBudgetByMonth)
+	for _, item := range getBudgetByMonth {
+		budgetMonthMap[item.Month] = item
+	}
+
+	var resBudgetByMonth []map[string]interface{}
+	for i := 0; i < budgetMonthCount; i++ {
+		month := budgetMonthStart.AddDate(0, i, 0).Format("2006-01")
+		var estimated, actual int64
+		if val, ok := budgetMonthMap[month]; ok {
+			estimated, actual = val.Estimated, val.Actual
+		}
+		resBudgetByMonth = append(resBudgetByMonth, map[string]interface{}{
+			"month":     month,
+			"estimated": estimated,
+			"actual":    actual,
+		})
+	}
+
 	res := make(map[string]interface{})
 
 	switch payload.RoleId {
 	case constant.ROLE_ID_STAF:
+		res["count_overdue"] = countOverdue
 		res["chart_by_status"] = resDashboardByStatus
 		res["chart_by_event_type"] = resDashboardByEventType
+		res["chart_budget_by_event_type"] = resBudgetByEventType
+		res["chart_budget_by_month"] = resBudgetByMonth
 
 	case constant.ROLE_ID_BM:
 		res["count_user"] = countAllUsers
 		res["count_use_priority"] = countUsePriority
 		res["count_request"] = countAllRequest
+		res["count_overdue"] = countOverdue
 		res["chart_by_status"] = resDashboardByStatus
 		res["chart_by_event_type"] = resDashboardByEventType
+		res["chart_budget_by_event_type"] = resBudgetByEventType
+		res["chart_budget_by_month"] = resBudgetByMonth
 
 	case constant.ROLE_ID_ADMIN:
+		res["count_overdue"] = countOverdue
 		res["chart_by_status"] = resDashboardByStatus
 		res["chart_by_event_type"] = resDashboardByEventType
+		res["chart_budget_by_event_type"] = resBudgetByEventType
+		res["chart_budget_by_month"] = resBudgetByMonth
 	}
 
 	return map[string]interface{}{
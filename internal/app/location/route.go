@@ -0,0 +1,19 @@
+package location
+
+import (
+	"bm_binus/internal/middleware"
+
+	"github.com/labstack/echo/v4"
+)
+
+func (h *handler) Route(v *echo.Group) {
+	v.GET("", h.Find, middleware.Authentication)
+	v.GET("/blackout", h.FindBlackout, middleware.Authentication)
+	v.POST("/blackout", h.CreateBlackout, middleware.Authentication)
+	v.DELETE("/blackout/:id", h.DeleteBlackout, middleware.Authentication)
+	v.GET("/:id", h.FindById, middleware.Authentication)
+	v.POST("", h.Create, middleware.Authentication)
+	v.POST("/migrate", h.Migrate, middleware.Authentication)
+	v.PUT("/:id", h.Update, middleware.Authentication)
+	v.DELETE("/:id", h.Delete, middleware.Authentication)
+}
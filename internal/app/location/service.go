@@ -0,0 +1,450 @@
+package location
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Find(ctx *abstraction.Context) (map[string]interface{}, error)
+	FindById(ctx *abstraction.Context, payload *dto.LocationFindByIDRequest) (map[string]interface{}, error)
+	Create(ctx *abstraction.Context, payload *dto.LocationCreateRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.LocationUpdateRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.LocationDeleteByIDRequest) (map[string]interface{}, error)
+	Migrate(ctx *abstraction.Context) (map[string]interface{}, error)
+	FindBlackout(ctx *abstraction.Context) (map[string]interface{}, error)
+	CreateBlackout(ctx *abstraction.Context, payload *dto.BlackoutDateCreateRequest) (map[string]interface{}, error)
+	DeleteBlackout(ctx *abstraction.Context, payload *dto.BlackoutDateDeleteByIDRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	LocationRepository     repository.Location
+	RequestRepository      repository.Request
+	BlackoutDateRepository repository.BlackoutDate
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		LocationRepository:     f.LocationRepository,
+		RequestRepository:      f.RequestRepository,
+		BlackoutDateRepository: f.BlackoutDateRepository,
+
+		DB: f.Db,
+	}
+}
+
+func locationResponse(v *model.LocationEntityModel) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         v.ID,
+		"name":       v.Name,
+		"building":   v.Building,
+		"floor":      v.Floor,
+		"capacity":   v.Capacity,
+		"facilities": v.Facilities,
+		"open_time":  v.OpenTime,
+		"close_time": v.CloseTime,
+		"is_active":  v.IsActive,
+		"created_at": v.CreatedAt,
+		"updated_at": v.UpdatedAt,
+	}
+}
+
+// validateOperatingHours: jam operasional format HH:MM, boleh kosong (berarti 24 jam)
+func validateOperatingHours(openTime string, closeTime string) error {
+	for _, v := range []string{openTime, closeTime} {
+		if v == "" {
+			continue
+		}
+		if _, err := time.Parse("15:04", v); err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "operating hours must use HH:MM format")
+		}
+	}
+	if openTime != "" && closeTime != "" && openTime >= closeTime {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "open time must be earlier than close time")
+	}
+	return nil
+}
+
+func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	data, err := s.LocationRepository.Find(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.LocationRepository.Count(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		res = append(res, locationResponse(v))
+	}
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+func (s *service) FindById(ctx *abstraction.Context, payload *dto.LocationFindByIDRequest) (map[string]interface{}, error) {
+	data, err := s.LocationRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if data == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+	}
+	return map[string]interface{}{
+		"data": locationResponse(data),
+	}, nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.LocationCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		existData, err := s.LocationRepository.FindByName(ctx, payload.Name)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if existData != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location name already exists")
+		}
+
+		if err = validateOperatingHours(payload.OpenTime, payload.CloseTime); err != nil {
+			return err
+		}
+
+		isActive := true
+		if payload.IsActive != nil {
+			isActive = *payload.IsActive
+		}
+		modelLocation := &model.LocationEntityModel{
+			Context: ctx,
+			LocationEntity: model.LocationEntity{
+				Name:       strings.TrimSpace(payload.Name),
+				Building:   payload.Building,
+				Floor:      payload.Floor,
+				Capacity:   payload.Capacity,
+				Facilities: payload.Facilities,
+				OpenTime:   payload.OpenTime,
+				CloseTime:  payload.CloseTime,
+				IsActive:   isActive,
+				IsDelete:   false,
+			},
+		}
+		if err = s.LocationRepository.Create(ctx, modelLocation).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) Update(ctx *abstraction.Context, payload *dto.LocationUpdateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		locationData, err := s.LocationRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if locationData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+		}
+
+		newLocationData := new(model.LocationEntityModel)
+		newLocationData.Context = ctx
+		newLocationData.ID = payload.ID
+		if payload.Name != nil {
+			existData, err := s.LocationRepository.FindByName(ctx, *payload.Name)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if existData != nil && existData.ID != payload.ID {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location name already exists")
+			}
+			newLocationData.Name = strings.TrimSpace(*payload.Name)
+		}
+		if payload.Building != nil {
+			newLocationData.Building = *payload.Building
+		}
+		if payload.Floor != nil {
+			newLocationData.Floor = *payload.Floor
+		}
+		if payload.Facilities != nil {
+			newLocationData.Facilities = *payload.Facilities
+		}
+
+		if err = s.LocationRepository.Update(ctx, newLocationData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		// nilai 0/false tidak ikut terupdate lewat struct, jadi diset eksplisit
+		columns := map[string]interface{}{}
+		if payload.Capacity != nil {
+			columns["capacity"] = *payload.Capacity
+		}
+		if payload.IsActive != nil {
+			columns["is_active"] = *payload.IsActive
+		}
+		if payload.OpenTime != nil || payload.CloseTime != nil {
+			openTime, closeTime := locationData.OpenTime, locationData.CloseTime
+			if payload.OpenTime != nil {
+				openTime = *payload.OpenTime
+			}
+			if payload.CloseTime != nil {
+				closeTime = *payload.CloseTime
+			}
+			if err = validateOperatingHours(openTime, closeTime); err != nil {
+				return err
+			}
+			columns["open_time"] = openTime
+			columns["close_time"] = closeTime
+		}
+		if len(columns) > 0 {
+			if err = s.LocationRepository.UpdateColumns(ctx, payload.ID, columns).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		// nama lokasi di request ikut disesuaikan agar pencarian dan export tetap konsisten
+		if newLocationData.Name != "" && newLocationData.Name != locationData.Name {
+			if err = s.RequestRepository.UpdateLocationName(ctx, payload.ID, newLocationData.Name).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.LocationDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		locationData, err := s.LocationRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if locationData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+		}
+
+		newLocationData := new(model.LocationEntityModel)
+		newLocationData.Context = ctx
+		newLocationData.ID = locationData.ID
+		newLocationData.IsDelete = true
+
+		if err = s.LocationRepository.Update(ctx, newLocationData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
+
+// Migrate menghubungkan request lama yang masih memakai event_location free-text ke tabel location.
+// Lokasi yang belum terdaftar dibuat otomatis dengan kapasitas 0 (tanpa batas) agar BM bisa melengkapinya.
+func (s *service) Migrate(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var (
+		createdLocation int
+		linkedRequest   int64
+	)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		locationNames, err := s.RequestRepository.FindUnlinkedLocation(ctx)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		for _, name := range locationNames {
+			locationData, err := s.LocationRepository.FindByName(ctx, name)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if locationData == nil {
+				locationData = &model.LocationEntityModel{
+					Context: ctx,
+					LocationEntity: model.LocationEntity{
+						Name:     name,
+						IsActive: true,
+						IsDelete: false,
+					},
+				}
+				if err = s.LocationRepository.Create(ctx, locationData).Error; err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				createdLocation++
+			}
+
+			result := s.RequestRepository.LinkLocation(ctx, name, locationData.ID)
+			if result.Error != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+			}
+			linkedRequest += result.RowsAffected
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message":          "success migrate!",
+		"created_location": createdLocation,
+		"linked_request":   linkedRequest,
+	}, nil
+}
+
+func (s *service) FindBlackout(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	data, err := s.BlackoutDateRepository.Find(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.BlackoutDateRepository.Count(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var locationRes map[string]interface{} = nil
+		if v.Location != nil {
+			locationRes = map[string]interface{}{
+				"id":   v.Location.ID,
+				"name": v.Location.Name,
+			}
+		}
+		res = append(res, map[string]interface{}{
+			"id":         v.ID,
+			"location":   locationRes,
+			"date_start": general.FormatWithZWithoutChangingTime(v.DateStart),
+			"date_end":   general.FormatWithZWithoutChangingTime(v.DateEnd),
+			"reason":     v.Reason,
+			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		})
+	}
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+func (s *service) CreateBlackout(ctx *abstraction.Context, payload *dto.BlackoutDateCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		parsedDateStart, err := general.Parse("2006-01-02 15:04:05", payload.DateStart)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse date start:"+err.Error())
+		}
+		parsedDateEnd, err := general.Parse("2006-01-02 15:04:05", payload.DateEnd)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse date end:"+err.Error())
+		}
+		if !parsedDateStart.Before(parsedDateEnd) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "Tanggal mulai harus lebih kecil dari tanggal selesai")
+		}
+
+		if payload.LocationId != nil {
+			locationData, err := s.LocationRepository.FindById(ctx, *payload.LocationId)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if locationData == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+			}
+		}
+
+		modelBlackout := &model.BlackoutDateEntityModel{
+			Context: ctx,
+			BlackoutDateEntity: model.BlackoutDateEntity{
+				LocationId: payload.LocationId,
+				DateStart:  parsedDateStart,
+				DateEnd:    parsedDateEnd,
+				Reason:     payload.Reason,
+				IsDelete:   false,
+			},
+		}
+		if err = s.BlackoutDateRepository.Create(ctx, modelBlackout).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) DeleteBlackout(ctx *abstraction.Context, payload *dto.BlackoutDateDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		blackoutData, err := s.BlackoutDateRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if blackoutData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "blackout date not found")
+		}
+
+		newBlackoutData := new(model.BlackoutDateEntityModel)
+		newBlackoutData.Context = ctx
+		newBlackoutData.ID = blackoutData.ID
+		newBlackoutData.IsDelete = true
+
+		if err = s.BlackoutDateRepository.Update(ctx, newBlackoutData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
@@ -0,0 +1,241 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+)
+
+// ahpRankItem: posisi dan skor satu request pada ranking AHP
+type ahpRankItem struct {
+	ID      int     `json:"id"`
+	Rank    int     `json:"rank"`
+	Score   float64 `json:"score"`
+	Percent float64 `json:"percent"`
+}
+
+// ahpRanking: hasil ranking AHP request open yang lolos filter, urut dari skor tertinggi
+type ahpRanking struct {
+	Items             []ahpRankItem `json:"items"`
+	Method            string        `json:"method"`
+	CriteriaWeights   []float64     `json:"criteria_weights"`
+	CriteriaLambdaMax float64       `json:"criteria_lambda_max"`
+	CriteriaCR        float64       `json:"criteria_cr"`
+}
+
+// ahpCriteriaMatrix memilih matriks perbandingan kriteria untuk ranking: profil yang diminta, profil aktif milik user,
+// atau bobot default jika keduanya tidak ada. Profil yang dipilih ikut dikembalikan untuk ditampilkan di response
+func ahpCriteriaMatrix(s *service, ctx *abstraction.Context, profileId *int) ([][]float64, map[string]interface{}, error) {
+	var (
+		profileData *model.AhpCriteriaProfileEntityModel
+		err         error
+	)
+	if profileId != nil {
+		profileData, err = s.AhpCriteriaProfileRepository.FindById(ctx, *profileId)
+		if err != nil && err.Error() != "record not found" {
+			return nil, nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if profileData == nil || profileData.UserId != ctx.Auth.ID {
+			return nil, nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "ahp criteria profile not found")
+		}
+	} else {
+		profileData, err = s.AhpCriteriaProfileRepository.FindActiveByUserId(ctx, ctx.Auth.ID)
+		if err != nil && err.Error() != "record not found" {
+			return nil, nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	if profileData == nil {
+		return general.BuildPairwiseFromScores(constant.AHP_CRITERIA_DEFAULT_SCORES), map[string]interface{}{
+			"profile": nil,
+			"version": nil,
+		}, nil
+	}
+
+	versionData, err := s.AhpCriteriaProfileRepository.FindVersion(ctx, profileData.ID, profileData.LatestVersion)
+	if err != nil && err.Error() != "record not found" {
+		return nil, nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if versionData == nil {
+		return nil, nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "ahp criteria profile has no version")
+	}
+	if versionData.ConsistencyRatio > constant.AHP_CR_THRESHOLD {
+		return nil, nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "ahp criteria profile is not consistent, revise the comparison first")
+	}
+
+	var matrix [][]float64
+	if err = json.Unmarshal([]byte(versionData.Matrix), &matrix); err != nil || len(matrix) != len(constant.AHP_CRITERIA) {
+		return nil, nil, response.ErrorBuilder(http.StatusInternalServerError, errors.New("invalid ahp criteria matrix"), "server_error")
+	}
+
+	return matrix, map[string]interface{}{
+		"profile": map[string]interface{}{
+			"id":   profileData.ID,
+			"name": profileData.Name,
+		},
+		"version":           versionData.Version,
+		"consistency_ratio": versionData.ConsistencyRatio,
+	}, nil
+}
+
+// rankRequests menghitung ranking AHP atas semua request yang masih open dan lolos filter listing (bukan hanya halaman
+// yang diminta).
+// Hasilnya di-cache di redis dengan key hash dari input ranking saja (tanpa limit/offset/order), jadi semua halaman
+// memakai ranking yang sama, dan begitu ada request yang dibuat, diubah, dihapus atau priority event type berubah,
+// key-nya ikut berubah dan ranking dihitung ulang
+func rankRequests(s *service, ctx *abstraction.Context, criteriaMatrix [][]float64, eventComplexity *string, method string) (*ahpRanking, error) {
+	inputData, err := s.RequestRepository.FindRankInput(ctx, openStatuses)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	complexity := ""
+	if eventComplexity != nil {
+		complexity = *eventComplexity
+	}
+	keySource, err := json.Marshal(map[string]interface{}{
+		"input":      inputData,
+		"criteria":   criteriaMatrix,
+		"complexity": complexity,
+		"method":     method,
+	})
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	hash := sha256.Sum256(keySource)
+	cacheKey := fmt.Sprintf(constant.REDIS_KEY_AHP_RANKING, hex.EncodeToString(hash[:]))
+
+	if cached, err := s.DbRedis.Get(context.Background(), cacheKey).Result(); err == nil {
+		var ranking ahpRanking
+		if err = json.Unmarshal([]byte(cached), &ranking); err == nil {
+			fmt.Println("-> Ranking AHP diambil dari cache")
+			return &ranking, nil
+		}
+	}
+
+	complexityMap := map[int]float64{}
+	if complexity != "" {
+		fmt.Println("-> Parsing EventComplexity JSON...")
+		complexityMap = general.ParseComplexities(complexity)
+		fmt.Println("   Hasil parsing complexityMap:", complexityMap)
+	}
+
+	var alts []general.AltRaw
+	for _, v := range inputData {
+		alts = append(alts, general.AltRaw{
+			ID:                v.ID,
+			EventName:         v.EventName,
+			EventDateStart:    v.EventDateStart,
+			EventTypePriority: v.EventTypePriority,
+			CountParticipant:  v.CountParticipant,
+			CreatedAt:         v.CreatedAt,
+		})
+	}
+	ranking := computeAhpRanking(alts, criteriaMatrix, complexityMap, method)
+
+	if cached, err := json.Marshal(ranking); err == nil {
+		s.DbRedis.Set(context.Background(), cacheKey, cached, time.Duration(constant.AHP_RANKING_CACHE_TTL_MINUTES)*time.Minute)
+	}
+	return ranking, nil
+}
+
+// computeAhpRanking: skor akhir = jumlah bobot kriteria x bobot alternatif pada kriteria tersebut.
+// Skor seri diurutkan berdasarkan id supaya urutan antar halaman tetap stabil
+func computeAhpRanking(alts []general.AltRaw, criteriaMatrix [][]float64, complexityMap map[int]float64, method string) *ahpRanking {
+	criteria := general.CalculateAHPWithMethod(criteriaMatrix, method)
+	criteriaWeights := criteria.Weights
+	fmt.Println("\n--- [KRITERIA UTAMA] ---")
+	fmt.Println("Nama:", constant.AHP_CRITERIA)
+	fmt.Println("\nMatriks Perbandingan Kriteria:")
+	general.PrintMatrix(criteriaMatrix)
+	fmt.Println("Bobot Kriteria:", criteriaWeights)
+	fmt.Printf("Method: %s, Lambda Max: %.4f\n", criteria.Method, criteria.LambdaMax)
+	fmt.Printf("CR (Consistency Ratio): %.4f\n", criteria.CR)
+
+	ranking := &ahpRanking{
+		Items:             []ahpRankItem{},
+		Method:            criteria.Method,
+		CriteriaWeights:   criteriaWeights,
+		CriteriaLambdaMax: criteria.LambdaMax,
+		CriteriaCR:        criteria.CR,
+	}
+	n := len(alts)
+	if n == 0 {
+		return ranking
+	}
+	fmt.Printf("-> Jumlah alternatif: %d\n", n)
+
+	// siapkan slice skor, urutannya mengikuti constant.AHP_CRITERIA
+	scores := make([][]float64, len(constant.AHP_CRITERIA))
+	for c := range scores {
+		scores[c] = make([]float64, n)
+	}
+	for i, a := range alts {
+		scores[0][i] = general.ComputeUrgencyScore(a.CreatedAt, a.EventDateStart)
+		priority := a.EventTypePriority
+		if priority <= 0 {
+			priority = 1
+		}
+		scores[1][i] = float64(1) / float64(priority)
+		scores[2][i] = float64(a.CountParticipant)
+
+		compVal := 1.0
+		if c, ok := complexityMap[a.ID]; ok {
+			compVal = c
+		}
+		scores[3][i] = 6.0 - compVal
+	}
+
+	// bobot alternatif per kriteria
+	finalScores := make([]float64, n)
+	for c, name := range constant.AHP_CRITERIA {
+		// matriks alternatif n x n tidak dibentuk, nilainya dihitung dari skor supaya semua request open bisa diranking
+		alt := general.CalculateAHPFromScores(scores[c], method)
+		fmt.Printf("\n--- [AHP %s] Lambda Max: %.4f, CR: %.4f ---\n", name, alt.LambdaMax, alt.CR)
+		for i := 0; i < n; i++ {
+			finalScores[i] += criteriaWeights[c] * alt.Weights[i]
+		}
+	}
+
+	total := 0.0
+	for i, a := range alts {
+		total += finalScores[i]
+		ranking.Items = append(ranking.Items, ahpRankItem{
+			ID:    a.ID,
+			Score: finalScores[i],
+		})
+	}
+	sort.SliceStable(ranking.Items, func(i, j int) bool {
+		if ranking.Items[i].Score == ranking.Items[j].Score {
+			return ranking.Items[i].ID < ranking.Items[j].ID
+		}
+		return ranking.Items[i].Score > ranking.Items[j].Score
+	})
+	if total == 0 {
+		total = 1 // biar gak bagi 0
+	}
+	for i := range ranking.Items {
+		ranking.Items[i].Rank = i + 1
+		ranking.Items[i].Percent = ranking.Items[i].Score / total * 100
+	}
+
+	fmt.Println("\n--- [RANKING AKHIR] ---")
+	for _, r := range ranking.Items {
+		if r.Rank > 10 {
+			break
+		}
+		fmt.Printf("%d. request %d (Score: %.6f)\n", r.Rank, r.ID, r.Score)
+	}
+	return ranking
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"testing"
+	"time"
+)
+
+// ranking sebelumnya dipotong di 200 request, semua request open harus tetap mendapat peringkat
+func TestComputeAhpRankingRanksEveryRequest(t *testing.T) {
+	const n = 450
+	now := time.Now()
+	alts := make([]general.AltRaw, n)
+	for i := range alts {
+		alts[i] = general.AltRaw{
+			ID:                i + 1,
+			EventDateStart:    now.Add(time.Duration(i%30+1) * 24 * time.Hour),
+			CreatedAt:         now.Add(-time.Duration(i%7) * 24 * time.Hour),
+			EventTypePriority: i%3 + 1,
+			CountParticipant:  i%50 + 10,
+		}
+	}
+	criteria := general.BuildPairwiseFromScores(constant.AHP_CRITERIA_DEFAULT_SCORES)
+
+	for _, method := range []string{constant.AHP_METHOD_APPROXIMATE, constant.AHP_METHOD_EIGENVECTOR} {
+		ranking := computeAhpRanking(alts, criteria, map[int]float64{}, method)
+		if len(ranking.Items) != n {
+			t.Fatalf("%s: ranked %d requests, want %d", method, len(ranking.Items), n)
+		}
+		seen := map[int]bool{}
+		for i, v := range ranking.Items {
+			if v.Rank != i+1 {
+				t.Fatalf("%s: item %d has rank %d", method, i, v.Rank)
+			}
+			if i > 0 && v.Score > ranking.Items[i-1].Score {
+				t.Fatalf("%s: rank %d scores higher than rank %d", method, v.Rank, v.Rank-1)
+			}
+			seen[v.ID] = true
+		}
+		if len(seen) != n {
+			t.Fatalf("%s: %d distinct requests ranked, want %d", method, len(seen), n)
+		}
+	}
+}
@@ -0,0 +1,389 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"bm_binus/pkg/ws"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// startApprovalChain menyalin approval step event type ke request saat masuk validasi lalu memberi notifikasi
+// ke approver urutan pertama, mengembalikan id user yang dinotifikasi untuk dipublish setelah trx
+func startApprovalChain(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) ([]int, error) {
+	if err := s.RequestApprovalRepository.DeleteByRequestId(ctx, requestData.ID).Error; err != nil {
+		return nil, err
+	}
+
+	steps, err := s.ApprovalStepRepository.FindByEventTypeId(ctx, requestData.EventTypeId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	if len(steps) == 0 {
+		return nil, nil
+	}
+
+	var approvals []*model.RequestApprovalEntityModel
+	for _, v := range steps {
+		modelApproval := &model.RequestApprovalEntityModel{
+			Context: ctx,
+			RequestApprovalEntity: model.RequestApprovalEntity{
+				RequestId: requestData.ID,
+				StepOrder: v.StepOrder,
+				RoleId:    v.RoleId,
+				UserId:    v.UserId,
+				Decision:  constant.APPROVAL_DECISION_PENDING,
+				IsDelete:  false,
+			},
+		}
+		if err = s.RequestApprovalRepository.Create(ctx, modelApproval).Error; err != nil {
+			return nil, err
+		}
+		approvals = append(approvals, modelApproval)
+	}
+
+	return notifyApprovers(s, ctx, requestData, approvals, currentApprovalStep(approvals))
+}
+
+// currentApprovalStep mengembalikan step_order terkecil yang masih punya approval pending, 0 jika rantai selesai
+func currentApprovalStep(approvals []*model.RequestApprovalEntityModel) int {
+	current := 0
+	for _, v := range approvals {
+		if v.Decision != constant.APPROVAL_DECISION_PENDING {
+			continue
+		}
+		if current == 0 || v.StepOrder < current {
+			current = v.StepOrder
+		}
+	}
+	return current
+}
+
+func matchApprover(approval *model.RequestApprovalEntityModel, userId int, roleId int) bool {
+	if approval.UserId != nil {
+		return *approval.UserId == userId
+	}
+	return approval.RoleId != nil && *approval.RoleId == roleId
+}
+
+// canDecideApproval mengecek hak user login atas approval, langsung atau mewakili user yang sedang cuti.
+// onBehalfOf berisi id user yang diwakili jika hak tersebut didapat dari delegasi
+func canDecideApproval(ctx *abstraction.Context, approval *model.RequestApprovalEntityModel, delegators []*model.UserEntityModel) (bool, *int) {
+	if matchApprover(approval, ctx.Auth.ID, ctx.Auth.RoleID) {
+		return true, nil
+	}
+	for _, v := range delegators {
+		if matchApprover(approval, v.ID, v.RoleId) {
+			return true, &v.ID
+		}
+	}
+	return false, nil
+}
+
+// notifyApprovers mengirim notifikasi ke approver yang masih pending pada step tersebut
+func notifyApprovers(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel, approvals []*model.RequestApprovalEntityModel, step int) ([]int, error) {
+	var userIds []int
+	for _, v := range approvals {
+		if v.StepOrder != step || v.Decision != constant.APPROVAL_DECISION_PENDING {
+			continue
+		}
+		if v.UserId != nil {
+			userIds = append(userIds, *v.UserId)
+			continue
+		}
+		users, err := s.UserRepository.FindByRoleIdArr(ctx, *v.RoleId, true)
+		if err != nil && err.Error() != "record not found" {
+			return nil, err
+		}
+		for _, u := range users {
+			userIds = append(userIds, u.ID)
+		}
+	}
+
+	// yang dikembalikan adalah penerima akhir (delegasi jika approver sedang cuti) untuk dipublish
+	var recipientIds []int
+	for _, v := range general.RemoveDuplicateArrayInt(userIds) {
+		recipient, err := SendNotif(s, ctx, "Persetujuan dibutuhkan!", requestData.EventName, v, requestData.ID)
+		if err != nil {
+			return nil, err
+		}
+		recipientIds = append(recipientIds, recipient)
+	}
+	return recipientIds, nil
+}
+
+// guardApprovalComplete: request dengan rantai persetujuan baru bisa diproses setelah semua approver setuju
+func guardApprovalComplete(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) (string, error) {
+	approvals, err := s.RequestApprovalRepository.FindByRequestId(ctx, requestData.ID)
+	if err != nil && err.Error() != "record not found" {
+		return "", err
+	}
+	if currentApprovalStep(approvals) != 0 {
+		return "approval chain is not complete", nil
+	}
+	return "", nil
+}
+
+func (s *service) FindApproval(ctx *abstraction.Context, payload *dto.RequestFindApprovalRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	data, err := s.RequestApprovalRepository.FindByRequestId(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	delegators, err := activeDelegators(s, ctx)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	currentStep := currentApprovalStep(data)
+	for _, v := range data {
+		var (
+			roleRes      map[string]interface{} = nil
+			userRes      map[string]interface{} = nil
+			decidedByRes map[string]interface{} = nil
+			onBehalfRes  map[string]interface{} = nil
+			decidedAt    interface{}            = nil
+		)
+		if v.Role != nil {
+			roleRes = map[string]interface{}{
+				"id":   v.Role.ID,
+				"name": v.Role.Name,
+			}
+		}
+		if v.User != nil {
+			userRes = map[string]interface{}{
+				"id":   v.User.ID,
+				"name": v.User.Name,
+			}
+		}
+		if v.DecidedByUser != nil {
+			decidedByRes = map[string]interface{}{
+				"id":   v.DecidedByUser.ID,
+				"name": v.DecidedByUser.Name,
+			}
+		}
+		if v.OnBehalf != nil {
+			onBehalfRes = map[string]interface{}{
+				"id":   v.OnBehalf.ID,
+				"name": v.OnBehalf.Name,
+			}
+		}
+		if v.DecidedAt != nil {
+			decidedAt = general.FormatWithZWithoutChangingTime(*v.DecidedAt)
+		}
+		canDecide, _ := canDecideApproval(ctx, v, delegators)
+		res = append(res, map[string]interface{}{
+			"id":           v.ID,
+			"step_order":   v.StepOrder,
+			"role":         roleRes,
+			"user":         userRes,
+			"decision":     v.Decision,
+			"comment":      v.Comment,
+			"decided_by":   decidedByRes,
+			"on_behalf_of": onBehalfRes,
+			"decided_at":   decidedAt,
+			"can_decide": requestData.StatusId == constant.STATUS_ID_VALIDASI &&
+				v.Decision == constant.APPROVAL_DECISION_PENDING &&
+				v.StepOrder == currentStep &&
+				canDecide,
+		})
+	}
+
+	return map[string]interface{}{
+		"current_step": currentStep,
+		"data":         res,
+	}, nil
+}
+
+// DecideApproval mencatat keputusan approver pada step yang sedang berjalan, penolakan langsung menolak request
+// dan jika seluruh rantai sudah setuju request otomatis diproses (selama guard lain terpenuhi)
+func (s *service) DecideApproval(ctx *abstraction.Context, payload *dto.RequestApprovalDecisionRequest) (map[string]interface{}, error) {
+	var (
+		sendNotifTo []int
+		message     = "success approve!"
+	)
+	comment := strings.TrimSpace(payload.Comment)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+		}
+		if requestData.StatusId != constant.STATUS_ID_VALIDASI {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not waiting for approval")
+		}
+
+		approvals, err := s.RequestApprovalRepository.FindByRequestId(ctx, requestData.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		step := currentApprovalStep(approvals)
+		delegators, err := activeDelegators(s, ctx)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		var (
+			approvalData *model.RequestApprovalEntityModel
+			onBehalfOf   *int
+		)
+		for _, v := range approvals {
+			if v.StepOrder != step || v.Decision != constant.APPROVAL_DECISION_PENDING {
+				continue
+			}
+			if ok, behalf := canDecideApproval(ctx, v, delegators); ok {
+				approvalData = v
+				onBehalfOf = behalf
+				break
+			}
+		}
+		if approvalData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("approval_not_permitted"), "you are not an approver of the current step")
+		}
+
+		decision := constant.APPROVAL_DECISION_APPROVED
+		if payload.Decision == "reject" {
+			if comment == "" {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("status_reason_required"), "comment is required to reject")
+			}
+			decision = constant.APPROVAL_DECISION_REJECTED
+		}
+
+		decidedBy := ctx.Auth.ID
+		approvalData.Decision = decision
+		approvalData.Comment = comment
+		approvalData.DecidedBy = &decidedBy
+		approvalData.OnBehalfOf = onBehalfOf
+		approvalData.DecidedAt = general.NowWithLocation()
+		if err = s.RequestApprovalRepository.Update(ctx, &model.RequestApprovalEntityModel{
+			ID:                    approvalData.ID,
+			RequestApprovalEntity: approvalData.RequestApprovalEntity,
+			Context:               ctx,
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		if decision == constant.APPROVAL_DECISION_REJECTED {
+			message = "success reject!"
+			newRequestData := new(model.RequestEntityModel)
+			newRequestData.Context = ctx
+			newRequestData.ID = requestData.ID
+			newRequestData.StatusId = constant.STATUS_ID_DITOLAK
+			newRequestData.StatusReason = comment
+			result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+			if result.Error != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+			}
+			if result.RowsAffected == 0 {
+				return response.ErrorVersionConflict(nil)
+			}
+			if err = createStatusHistory(s, ctx, requestData.ID, &requestData.StatusId, constant.STATUS_ID_DITOLAK, comment, onBehalfOf); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			recipient, err := SendNotif(s, ctx, "Event ditolak!", fmt.Sprintf("%s: %s", requestData.EventName, comment), requestData.UserId, requestData.ID)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			sendNotifTo = append(sendNotifTo, recipient)
+			return nil
+		}
+
+		nextStep := currentApprovalStep(approvals)
+		if nextStep != 0 {
+			if nextStep != step {
+				userIds, err := notifyApprovers(s, ctx, requestData, approvals, nextStep)
+				if err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				sendNotifTo = append(sendNotifTo, userIds...)
+			}
+			return nil
+		}
+
+		// rantai selesai, request diproses jika guard lain (mis. file wajib) juga terpenuhi
+		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		guardReason, err := checkStatusGuards(s, ctx, requestData, findStatusTransition(constant.STATUS_ID_VALIDASI, constant.STATUS_ID_PROSES))
+		if err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if guardReason != "" {
+			message = "success approve! request is not processed yet: " + guardReason
+			for _, v := range requestData.HandlerUsers(userBM) {
+				recipient, err := SendNotif(s, ctx, "Persetujuan selesai!", fmt.Sprintf("%s: %s", requestData.EventName, guardReason), v.ID, requestData.ID)
+				if err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				sendNotifTo = append(sendNotifTo, recipient)
+			}
+			return nil
+		}
+
+		newRequestData := new(model.RequestEntityModel)
+		newRequestData.Context = ctx
+		newRequestData.ID = requestData.ID
+		newRequestData.StatusId = constant.STATUS_ID_PROSES
+		result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+		if err = createStatusHistory(s, ctx, requestData.ID, &requestData.StatusId, constant.STATUS_ID_PROSES, "approval chain completed", onBehalfOf); err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		userAdmin, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_ADMIN, true)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		sendNotifTo = append(sendNotifTo, requestData.UserId)
+		for _, v := range requestData.HandlerUsers(userBM) {
+			sendNotifTo = append(sendNotifTo, v.ID)
+		}
+		for _, v := range userAdmin {
+			sendNotifTo = append(sendNotifTo, v.ID)
+		}
+		sendNotifTo = general.RemoveDuplicateArrayInt(sendNotifTo)
+		for i, v := range sendNotifTo {
+			if sendNotifTo[i], err = SendNotif(s, ctx, "Event diperbarui!", requestData.EventName, v, requestData.ID); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range general.RemoveDuplicateArrayInt(sendNotifTo) {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": message,
+	}, nil
+}
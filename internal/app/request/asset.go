@@ -0,0 +1,205 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// assetShortages membandingkan pesanan aset request dengan sisa stok pada rentang waktunya.
+// Stok hanya dikurangi pesanan request lain yang sudah disetujui (constant.ASSET_BOOKED_STATUS_IDS)
+func assetShortages(s *service, ctx *abstraction.Context, requestId int, items []*model.RequestAssetEntityModel, start time.Time, end time.Time, eventType *model.EventTypeEntityModel) ([]map[string]interface{}, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	var assetIds []int
+	for _, v := range items {
+		assetIds = append(assetIds, v.AssetId)
+	}
+	windowStart, windowEnd := bookingWindow(start, end, eventType)
+	reserved, err := s.RequestAssetRepository.SumReserved(ctx, assetIds, windowStart, windowEnd, requestId, constant.ASSET_BOOKED_STATUS_IDS)
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	reservedMap := make(map[int]int)
+	for _, v := range reserved {
+		reservedMap[v.AssetID] = v.Total
+	}
+
+	var res []map[string]interface{} = nil
+	for _, v := range items {
+		available := v.Asset.Quantity - reservedMap[v.AssetId]
+		if available < 0 {
+			available = 0
+		}
+		if v.Quantity <= available {
+			continue
+		}
+		res = append(res, map[string]interface{}{
+			"asset_id":  v.AssetId,
+			"name":      v.Asset.Name,
+			"requested": v.Quantity,
+			"available": available,
+		})
+	}
+	return res, nil
+}
+
+// checkAssetShortage memastikan stok aset yang dipesan request cukup, jika tidak error berisi daftar kekurangan
+func checkAssetShortage(s *service, ctx *abstraction.Context, requestId int, start time.Time, end time.Time, eventType *model.EventTypeEntityModel) error {
+	items, err := s.RequestAssetRepository.FindByRequestId(ctx, requestId)
+	if err != nil && err.Error() != "record not found" {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	shortages, err := assetShortages(s, ctx, requestId, items, start, end, eventType)
+	if err != nil {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if len(shortages) == 0 {
+		return nil
+	}
+	return response.ErrorBuilderWithData(
+		http.StatusBadRequest,
+		errors.New("asset_shortage"),
+		"Stok aset tidak mencukupi",
+		map[string]interface{}{
+			"shortages": shortages,
+		},
+	)
+}
+
+func guardAssetAvailable(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) (string, error) {
+	items, err := s.RequestAssetRepository.FindByRequestId(ctx, requestData.ID)
+	if err != nil && err.Error() != "record not found" {
+		return "", err
+	}
+	shortages, err := assetShortages(s, ctx, requestData.ID, items, requestData.EventDateStart, requestData.EventDateEnd, &requestData.EventType)
+	if err != nil {
+		return "", err
+	}
+	if len(shortages) > 0 {
+		return fmt.Sprintf("asset stock is not sufficient for %s", shortages[0]["name"]), nil
+	}
+	return "", nil
+}
+
+func (s *service) FindAsset(ctx *abstraction.Context, payload *dto.RequestFindAssetRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	items, err := s.RequestAssetRepository.FindByRequestId(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	shortages, err := assetShortages(s, ctx, requestData.ID, items, requestData.EventDateStart, requestData.EventDateEnd, &requestData.EventType)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range items {
+		res = append(res, map[string]interface{}{
+			"id":        v.ID,
+			"asset_id":  v.AssetId,
+			"name":      v.Asset.Name,
+			"item_type": v.Asset.ItemType,
+			"quantity":  v.Quantity,
+		})
+	}
+
+	return map[string]interface{}{
+		"data":      res,
+		"shortages": shortages,
+	}, nil
+}
+
+// UpdateAsset mengganti seluruh pesanan aset. Pemilik boleh mengubah selama draft/pengajuan, BM dan admin selama request masih berjalan.
+// Request yang sudah diajukan langsung dicek kekurangan stoknya
+func (s *service) UpdateAsset(ctx *abstraction.Context, payload *dto.RequestAssetRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+		}
+
+		isOwner := requestData.UserId == ctx.Auth.ID
+		isManager := ctx.Auth.RoleID == constant.ROLE_ID_BM || ctx.Auth.RoleID == constant.ROLE_ID_ADMIN
+		ownerStatuses := []int{constant.STATUS_ID_DRAFT, constant.STATUS_ID_PENGAJUAN}
+		if !(isOwner && slices.Contains(ownerStatuses, requestData.StatusId)) && !(isManager && slices.Contains(openStatuses, requestData.StatusId)) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		if err = s.RequestAssetRepository.DeleteByRequestId(ctx, payload.ID).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		seen := make(map[int]bool)
+		for _, v := range payload.Assets {
+			if seen[v.AssetId] {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "duplicate asset_id in assets")
+			}
+			seen[v.AssetId] = true
+
+			assetData, err := s.AssetRepository.FindById(ctx, v.AssetId)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if assetData == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "asset not found")
+			}
+			if !assetData.IsActive {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("asset_inactive"), "asset is not active")
+			}
+
+			modelRequestAsset := &model.RequestAssetEntityModel{
+				Context: ctx,
+				RequestAssetEntity: model.RequestAssetEntity{
+					RequestId: payload.ID,
+					AssetId:   v.AssetId,
+					Quantity:  v.Quantity,
+					IsDelete:  false,
+				},
+			}
+			if err = s.RequestAssetRepository.Create(ctx, modelRequestAsset).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		if requestData.StatusId != constant.STATUS_ID_DRAFT {
+			if err = checkAssetShortage(s, ctx, requestData.ID, requestData.EventDateStart, requestData.EventDateEnd, &requestData.EventType); err != nil {
+				return err
+			}
+		}
+
+		newRequestData := new(model.RequestEntityModel)
+		newRequestData.Context = ctx
+		newRequestData.ID = payload.ID
+		newRequestData.UpdatedAt = general.NowLocal()
+		if err = s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
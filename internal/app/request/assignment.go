@@ -0,0 +1,170 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"bm_binus/pkg/ws"
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+)
+
+// openStatuses: status yang masih ditangani BM/admin, dipakai untuk menghitung beban assignee
+var openStatuses = []int{
+	constant.STATUS_ID_PENGAJUAN,
+	constant.STATUS_ID_VALIDASI,
+	constant.STATUS_ID_PROSES,
+	constant.STATUS_ID_FINALISASI,
+}
+
+// pickAssignee memilih BM untuk request sesuai strategi event type (dialihkan ke delegasi jika BM tersebut cuti),
+// nil jika strategi manual atau tidak ada BM
+func pickAssignee(s *service, ctx *abstraction.Context, eventTypeData *model.EventTypeEntityModel) (*model.UserEntityModel, error) {
+	if eventTypeData.AssignStrategy != constant.ASSIGN_STRATEGY_ROUND_ROBIN && eventTypeData.AssignStrategy != constant.ASSIGN_STRATEGY_LEAST_LOADED {
+		return nil, nil
+	}
+
+	userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	if len(userBM) == 0 {
+		return nil, nil
+	}
+	sort.Slice(userBM, func(i, j int) bool {
+		return userBM[i].ID < userBM[j].ID
+	})
+
+	if eventTypeData.AssignStrategy == constant.ASSIGN_STRATEGY_ROUND_ROBIN {
+		count, err := s.DbRedis.Incr(context.Background(), constant.REDIS_KEY_ASSIGN_ROUND_ROBIN).Result()
+		if err != nil {
+			return nil, err
+		}
+		return resolveDelegate(s, ctx, userBM[int((count-1)%int64(len(userBM)))])
+	}
+
+	loads, err := s.RequestRepository.CountByAssignee(ctx, openStatuses)
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	loadMap := make(map[int]int)
+	for _, v := range loads {
+		loadMap[v.AssigneeID] = v.Total
+	}
+	picked := userBM[0]
+	for _, v := range userBM[1:] {
+		if loadMap[v.ID] < loadMap[picked.ID] {
+			picked = v
+		}
+	}
+	return resolveDelegate(s, ctx, picked)
+}
+
+// findAssignee memastikan user tujuan penugasan ada dan berperan sebagai BM atau admin,
+// jika user tersebut sedang cuti penugasan dialihkan ke delegasinya
+func findAssignee(s *service, ctx *abstraction.Context, assigneeId int) (*model.UserEntityModel, error) {
+	userData, err := s.UserRepository.FindById(ctx, assigneeId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if userData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "assignee not found")
+	}
+	if userData.RoleId != constant.ROLE_ID_BM && userData.RoleId != constant.ROLE_ID_ADMIN {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "assignee must be BM or admin")
+	}
+	userData, err = resolveDelegate(s, ctx, userData)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	return userData, nil
+}
+
+// assignRequest menyimpan assignee baru lalu memberi notifikasi ke assignee tersebut,
+// id penerima notifikasinya dikembalikan (0 jika tidak ada) untuk dipublish setelah transaksi
+func assignRequest(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel, assignee *model.UserEntityModel) (int, error) {
+	if !slices.Contains(openStatuses, requestData.StatusId) {
+		return 0, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not open")
+	}
+
+	newRequestData := new(model.RequestEntityModel)
+	newRequestData.Context = ctx
+	newRequestData.ID = requestData.ID
+	newRequestData.AssigneeId = &assignee.ID
+	result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+	if result.Error != nil {
+		return 0, response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+	}
+	if result.RowsAffected == 0 {
+		return 0, response.ErrorVersionConflict(nil)
+	}
+
+	if assignee.ID == ctx.Auth.ID {
+		return 0, nil
+	}
+	recipient, err := SendNotif(s, ctx, "Event ditugaskan!", requestData.EventName, assignee.ID, requestData.ID)
+	if err != nil {
+		return 0, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	return recipient, nil
+}
+
+func (s *service) Assign(ctx *abstraction.Context, payload *dto.RequestAssignRequest) (map[string]interface{}, error) {
+	var (
+		assignee    *model.UserEntityModel
+		sendNotifTo int
+	)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+		}
+
+		if payload.AssigneeId != nil {
+			assignee, err = findAssignee(s, ctx, *payload.AssigneeId)
+			if err != nil {
+				return err
+			}
+		} else {
+			assignee, err = pickAssignee(s, ctx, &requestData.EventType)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if assignee == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "assignee_id is required for manual assignment")
+			}
+		}
+
+		sendNotifTo, err = assignRequest(s, ctx, requestData, assignee)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	if sendNotifTo != 0 {
+		if err := ws.PublishNotificationWithoutTransaction(sendNotifTo, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success assign!",
+		"assignee": map[string]interface{}{
+			"id":   assignee.ID,
+			"name": assignee.Name,
+		},
+	}, nil
+}
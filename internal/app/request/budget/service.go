@@ -0,0 +1,271 @@
+package budget
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Create(ctx *abstraction.Context, payload *dto.RequestBudgetCreateRequest) (map[string]interface{}, error)
+	FindByRequestId(ctx *abstraction.Context, payload *dto.RequestBudgetFindByRequestIDRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.RequestBudgetDeleteByIDRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.RequestBudgetUpdateRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	RequestBudgetRepository repository.RequestBudget
+	RequestRepository       repository.Request
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		RequestBudgetRepository: f.RequestBudgetRepository,
+		RequestRepository:       f.RequestRepository,
+
+		DB: f.Db,
+	}
+}
+
+// ownerStatuses: pemilik request hanya boleh mengubah estimasi sebelum event diproses
+var ownerStatuses = []int{
+	constant.STATUS_ID_DRAFT,
+	constant.STATUS_ID_PENGAJUAN,
+	constant.STATUS_ID_VALIDASI,
+}
+
+// closedStatuses: anggaran request yang ditolak/dibatalkan tidak bisa diubah lagi
+var closedStatuses = []int{
+	constant.STATUS_ID_DITOLAK,
+	constant.STATUS_ID_DIBATALKAN,
+}
+
+// checkBudgetPermission: BM dan admin boleh mengubah anggaran selama request belum ditutup,
+// pemilik hanya boleh mengubah estimasi selama ownerStatuses, realisasi biaya hanya diisi BM/admin
+func checkBudgetPermission(ctx *abstraction.Context, requestData *model.RequestEntityModel, withActual bool) error {
+	if slices.Contains(closedStatuses, requestData.StatusId) {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is already closed")
+	}
+	if ctx.Auth.RoleID == constant.ROLE_ID_BM || ctx.Auth.RoleID == constant.ROLE_ID_ADMIN {
+		return nil
+	}
+	if requestData.UserId != ctx.Auth.ID || !slices.Contains(ownerStatuses, requestData.StatusId) {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+	}
+	if withActual {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "only BM or admin can set actual_amount")
+	}
+	return nil
+}
+
+func findRequest(s *service, ctx *abstraction.Context, requestId int) (*model.RequestEntityModel, error) {
+	requestData, err := s.RequestRepository.FindById(ctx, requestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+	return requestData, nil
+}
+
+func touchRequest(s *service, ctx *abstraction.Context, requestId int) error {
+	newRequestData := new(model.RequestEntityModel)
+	newRequestData.Context = ctx
+	newRequestData.ID = requestId
+	newRequestData.UpdatedAt = general.NowLocal()
+	if err := s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	return nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestBudgetCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := findRequest(s, ctx, payload.RequestId)
+		if err != nil {
+			return err
+		}
+		if err = checkBudgetPermission(ctx, requestData, payload.ActualAmount != nil); err != nil {
+			return err
+		}
+		if strings.TrimSpace(payload.Category) == "" {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "category is required")
+		}
+
+		modelBudget := &model.RequestBudgetEntityModel{
+			Context: ctx,
+			RequestBudgetEntity: model.RequestBudgetEntity{
+				RequestId:       payload.RequestId,
+				Category:        strings.TrimSpace(payload.Category),
+				Description:     payload.Description,
+				EstimatedAmount: payload.EstimatedAmount,
+				ActualAmount:    payload.ActualAmount,
+				IsDelete:        false,
+			},
+		}
+		if err = s.RequestBudgetRepository.Create(ctx, modelBudget).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return touchRequest(s, ctx, payload.RequestId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) FindByRequestId(ctx *abstraction.Context, payload *dto.RequestBudgetFindByRequestIDRequest) (map[string]interface{}, error) {
+	var (
+		res            []map[string]interface{} = nil
+		totalEstimated int64
+		totalActual    int64
+	)
+
+	if _, err := findRequest(s, ctx, payload.RequestId); err != nil {
+		return nil, err
+	}
+
+	data, err := s.RequestBudgetRepository.FindByRequestId(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range data {
+		totalEstimated += v.EstimatedAmount
+		if v.ActualAmount != nil {
+			totalActual += *v.ActualAmount
+		}
+		res = append(res, map[string]interface{}{
+			"id":               v.ID,
+			"request_id":       v.RequestId,
+			"category":         v.Category,
+			"description":      v.Description,
+			"estimated_amount": v.EstimatedAmount,
+			"actual_amount":    v.ActualAmount,
+			"created_at":       general.FormatWithZWithoutChangingTime(v.CreatedAt),
+			"created_by": map[string]interface{}{
+				"id":   v.CreateBy.ID,
+				"name": v.CreateBy.Name,
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"count":           len(data),
+		"total_estimated": totalEstimated,
+		"total_actual":    totalActual,
+		"variance":        totalActual - totalEstimated,
+		"data":            res,
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.RequestBudgetDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		budgetData, err := s.RequestBudgetRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if budgetData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "budget not found")
+		}
+
+		requestData, err := findRequest(s, ctx, budgetData.RequestId)
+		if err != nil {
+			return err
+		}
+		if err = checkBudgetPermission(ctx, requestData, budgetData.ActualAmount != nil); err != nil {
+			return err
+		}
+
+		newBudgetData := new(model.RequestBudgetEntityModel)
+		newBudgetData.Context = ctx
+		newBudgetData.ID = payload.ID
+		newBudgetData.IsDelete = true
+		if err = s.RequestBudgetRepository.Update(ctx, newBudgetData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return touchRequest(s, ctx, budgetData.RequestId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
+
+func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestBudgetUpdateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		budgetData, err := s.RequestBudgetRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if budgetData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "budget not found")
+		}
+
+		requestData, err := findRequest(s, ctx, budgetData.RequestId)
+		if err != nil {
+			return err
+		}
+		if err = checkBudgetPermission(ctx, requestData, payload.ActualAmount != nil); err != nil {
+			return err
+		}
+
+		newBudgetData := new(model.RequestBudgetEntityModel)
+		newBudgetData.Context = ctx
+		newBudgetData.ID = payload.ID
+		if payload.Category != nil {
+			if strings.TrimSpace(*payload.Category) == "" {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "category is required")
+			}
+			newBudgetData.Category = strings.TrimSpace(*payload.Category)
+		}
+		if payload.Description != nil {
+			newBudgetData.Description = *payload.Description
+		}
+		if err = s.RequestBudgetRepository.Update(ctx, newBudgetData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		// nominal 0 tidak ikut terupdate lewat struct, jadi diset eksplisit
+		columns := map[string]interface{}{}
+		if payload.EstimatedAmount != nil {
+			columns["estimated_amount"] = *payload.EstimatedAmount
+		}
+		if payload.ActualAmount != nil {
+			columns["actual_amount"] = *payload.ActualAmount
+		}
+		if len(columns) > 0 {
+			if err = s.RequestBudgetRepository.UpdateColumns(ctx, payload.ID, columns).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return touchRequest(s, ctx, budgetData.RequestId)
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
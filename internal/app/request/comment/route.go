@@ -11,4 +11,6 @@ func (h *Handler) Route(v *echo.Group) {
 	v.GET("/:request_id", h.FindByRequestId, middleware.Authentication)
 	v.DELETE("/:id", h.Delete, middleware.Authentication)
 	v.PUT("/:id", h.Update, middleware.Authentication)
+	v.GET("/trash/:request_id", h.FindTrash, middleware.Authentication)
+	v.PATCH("/:id/restore", h.Restore, middleware.Authentication)
 }
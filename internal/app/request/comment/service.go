@@ -23,6 +23,8 @@ type Service interface {
 	FindByRequestId(ctx *abstraction.Context, payload *dto.CommentFindByRequestIDRequest) (map[string]interface{}, error)
 	Delete(ctx *abstraction.Context, payload *dto.CommentDeleteByIDRequest) (map[string]interface{}, error)
 	Update(ctx *abstraction.Context, payload *dto.CommentUpdateRequest) (map[string]interface{}, error)
+	FindTrash(ctx *abstraction.Context, payload *dto.CommentFindTrashRequest) (map[string]interface{}, error)
+	Restore(ctx *abstraction.Context, payload *dto.CommentRestoreRequest) (map[string]interface{}, error)
 }
 
 type service struct {
@@ -30,6 +32,7 @@ type service struct {
 	RequestRepository      repository.Request
 	NotificationRepository repository.Notification
 	UserRepository         repository.User
+	DelegationRepository   repository.Delegation
 
 	DB *gorm.DB
 }
@@ -40,26 +43,15 @@ func NewService(f *factory.Factory) Service {
 		RequestRepository:      f.RequestRepository,
 		NotificationRepository: f.NotificationRepository,
 		UserRepository:         f.UserRepository,
+		DelegationRepository:   f.DelegationRepository,
 
 		DB: f.Db,
 	}
 }
 
-func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) error {
-	modelNotification := &model.NotificationEntityModel{
-		Context: ctx,
-		NotificationEntity: model.NotificationEntity{
-			Title:     title,
-			Message:   message,
-			IsRead:    false,
-			UserId:    userId,
-			RequestId: requestId,
-		},
-	}
-	if err := s.NotificationRepository.Create(ctx, modelNotification).Error; err != nil {
-		return err
-	}
-	return nil
+// SendNotif: lihat repository.SendNotification, mengembalikan id penerima akhir (delegasi jika user sedang cuti)
+func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) (int, error) {
+	return repository.SendNotification(ctx, s.NotificationRepository, s.DelegationRepository, title, message, userId, requestId)
 }
 
 func (s *service) Create(ctx *abstraction.Context, payload *dto.CommentCreateRequest) (map[string]interface{}, error) {
@@ -100,6 +92,11 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.CommentCreateReq
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
 
+		// draft belum diajukan ke BM, jadi tidak ada notifikasi
+		if requestData.StatusId == constant.STATUS_ID_DRAFT {
+			return nil
+		}
+
 		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
 		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
@@ -115,7 +112,7 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.CommentCreateReq
 		}
 		switch ctx.Auth.RoleID {
 		case constant.ROLE_ID_STAF:
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 			if slices.Contains(statusesForAdmin, requestData.StatusId) {
 				addIDs(userAdmin)
 			}
@@ -126,11 +123,11 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.CommentCreateReq
 			}
 		case constant.ROLE_ID_ADMIN:
 			sendNotifTo = append(sendNotifTo, requestData.UserId)
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 		}
 
-		for _, v := range sendNotifTo {
-			err = SendNotif(s, ctx, "Komentar Baru!", payload.Comment, v, requestData.ID)
+		for i, v := range sendNotifTo {
+			sendNotifTo[i], err = SendNotif(s, ctx, "Komentar Baru!", payload.Comment, v, requestData.ID)
 			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
@@ -215,6 +212,7 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.CommentDeleteByI
 		newCommentData.Context = ctx
 		newCommentData.ID = payload.ID
 		newCommentData.IsDelete = true
+		newCommentData.DeletedAt = general.NowWithLocation()
 		if err = s.CommentRepository.Update(ctx, newCommentData).Error; err != nil {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
@@ -273,3 +271,89 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.CommentUpdateReq
 		"message": "success update!",
 	}, nil
 }
+
+func (s *service) FindTrash(ctx *abstraction.Context, payload *dto.CommentFindTrashRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	requestData, err := s.RequestRepository.FindById(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	data, err := s.CommentRepository.FindTrashByRequestId(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var deletedAt interface{} = nil
+		if v.DeletedAt != nil {
+			deletedAt = general.FormatWithZWithoutChangingTime(*v.DeletedAt)
+		}
+		res = append(res, map[string]interface{}{
+			"id":         v.ID,
+			"request_id": v.RequestId,
+			"comment":    v.Comment,
+			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
+			"deleted_at": deletedAt,
+			"created_by": map[string]interface{}{
+				"id":   v.CreateBy.ID,
+				"name": v.CreateBy.Name,
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"count": len(data),
+		"data":  res,
+	}, nil
+}
+
+// Restore: komentar hanya bisa dikembalikan oleh penulisnya atau BM/admin selama request-nya belum dihapus
+func (s *service) Restore(ctx *abstraction.Context, payload *dto.CommentRestoreRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		commentData, err := s.CommentRepository.FindDeletedById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if commentData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "comment not found in trash")
+		}
+		if commentData.CreatedBy != ctx.Auth.ID && ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		requestData, err := s.RequestRepository.FindById(ctx, commentData.RequestId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request of this comment is deleted, restore the request first")
+		}
+
+		if err = s.CommentRepository.UpdateColumns(ctx, payload.ID, map[string]interface{}{
+			"is_delete":  false,
+			"deleted_at": nil,
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		newRequestData := new(model.RequestEntityModel)
+		newRequestData.Context = ctx
+		newRequestData.ID = commentData.RequestId
+		newRequestData.UpdatedAt = general.NowLocal()
+		if err = s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": "success restore!",
+	}, nil
+}
@@ -0,0 +1,226 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	slotStepMinutes   = 30
+	slotSearchDays    = 14
+	slotSuggestionMax = 5
+)
+
+// bookingSchedule: jadwal request dan blackout pada satu lokasi, dipakai untuk cek slot tanpa query berulang
+type bookingSchedule struct {
+	location  *model.LocationEntityModel
+	requests  []*model.RequestEntityModel
+	blackouts []*model.BlackoutDateEntityModel
+}
+
+// bookingWindow mengembalikan rentang waktu event yang sudah ditambah buffer setup/teardown dari event type
+func bookingWindow(start time.Time, end time.Time, eventType *model.EventTypeEntityModel) (time.Time, time.Time) {
+	if eventType == nil {
+		return start, end
+	}
+	return start.Add(-time.Duration(eventType.SetupMinutes) * time.Minute),
+		end.Add(time.Duration(eventType.TeardownMinutes) * time.Minute)
+}
+
+// findBookableLocation memastikan lokasi ada, aktif dan kapasitasnya cukup (kapasitas 0 berarti tanpa batas)
+func findBookableLocation(s *service, ctx *abstraction.Context, locationId int, countParticipant int) (*model.LocationEntityModel, error) {
+	locationData, err := s.LocationRepository.FindById(ctx, locationId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if locationData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+	}
+	if !locationData.IsActive {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("location_inactive"), "location is not active")
+	}
+	if locationData.Capacity > 0 && countParticipant > locationData.Capacity {
+		return nil, response.ErrorBuilder(
+			http.StatusBadRequest,
+			errors.New("location_capacity_exceeded"),
+			fmt.Sprintf("Jumlah peserta melebihi kapasitas lokasi (%d orang)", locationData.Capacity),
+		)
+	}
+	return locationData, nil
+}
+
+func loadBookingSchedule(s *service, ctx *abstraction.Context, locationData *model.LocationEntityModel, excludeId int, from time.Time, to time.Time) (*bookingSchedule, error) {
+	requests, err := s.RequestRepository.FindOverlap(ctx, locationData.ID, from, to, excludeId, nonBookingStatuses)
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	blackouts, err := s.BlackoutDateRepository.FindOverlap(ctx, locationData.ID, from, to)
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	return &bookingSchedule{
+		location:  locationData,
+		requests:  requests,
+		blackouts: blackouts,
+	}, nil
+}
+
+// withinOperatingHours: jam operasional berlaku per hari, jadi booking yang melewati pergantian hari
+// di lokasi yang punya jam operasional pasti ditolak (jam tutup di hari pertama terlewati)
+func (b *bookingSchedule) withinOperatingHours(start time.Time, end time.Time) bool {
+	if b.location.OpenTime == "" && b.location.CloseTime == "" {
+		return true
+	}
+	start, end = start.In(general.Location()), end.In(general.Location())
+	if start.Format("2006-01-02") != end.Format("2006-01-02") {
+		return false
+	}
+	if b.location.OpenTime != "" && start.Format("15:04") < b.location.OpenTime {
+		return false
+	}
+	if b.location.CloseTime != "" && end.Format("15:04") > b.location.CloseTime {
+		return false
+	}
+	return true
+}
+
+// check mengembalikan kode alasan jika slot tidak bisa dipakai, string kosong jika slot tersedia
+func (b *bookingSchedule) check(start time.Time, end time.Time, eventType *model.EventTypeEntityModel) (string, []*model.RequestEntityModel, []*model.BlackoutDateEntityModel) {
+	if !b.withinOperatingHours(start, end) {
+		return "outside_operating_hours", nil, nil
+	}
+
+	var blackouts []*model.BlackoutDateEntityModel
+	for _, v := range b.blackouts {
+		if v.DateStart.Before(end) && v.DateEnd.After(start) {
+			blackouts = append(blackouts, v)
+		}
+	}
+	if len(blackouts) > 0 {
+		return "blackout_date", nil, blackouts
+	}
+
+	var conflicts []*model.RequestEntityModel
+	windowStart, windowEnd := bookingWindow(start, end, eventType)
+	for _, v := range b.requests {
+		existStart, existEnd := bookingWindow(v.EventDateStart, v.EventDateEnd, &v.EventType)
+		if existStart.Before(windowEnd) && existEnd.After(windowStart) {
+			conflicts = append(conflicts, v)
+		}
+	}
+	if len(conflicts) > 0 {
+		return "event_date_conflict", conflicts, nil
+	}
+
+	return "", nil, nil
+}
+
+// suggest mencari slot kosong terdekat dengan durasi yang sama, bergeser per slotStepMinutes
+func (b *bookingSchedule) suggest(start time.Time, end time.Time, eventType *model.EventTypeEntityModel) []map[string]interface{} {
+	var (
+		res      []map[string]interface{} = nil
+		duration                          = end.Sub(start)
+		step                              = time.Duration(slotStepMinutes) * time.Minute
+		maxStep                           = slotSearchDays * 24 * 60 / slotStepMinutes
+		now                               = general.NowWithLocation()
+	)
+	for i := 1; i <= maxStep && len(res) < slotSuggestionMax; i++ {
+		for _, offset := range []time.Duration{time.Duration(i) * step, -time.Duration(i) * step} {
+			candidateStart := start.Add(offset)
+			if candidateStart.Before(*now) {
+				continue
+			}
+			candidateEnd := candidateStart.Add(duration)
+			if reason, _, _ := b.check(candidateStart, candidateEnd, eventType); reason != "" {
+				continue
+			}
+			res = append(res, map[string]interface{}{
+				"event_date_start": candidateStart.Format("2006-01-02 15:04:05"),
+				"event_date_end":   candidateEnd.Format("2006-01-02 15:04:05"),
+			})
+			if len(res) >= slotSuggestionMax {
+				break
+			}
+		}
+	}
+	return res
+}
+
+func slotReasonMessage(reason string, locationData *model.LocationEntityModel) string {
+	switch reason {
+	case "outside_operating_hours":
+		return fmt.Sprintf("Waktu event di luar jam operasional lokasi (%s - %s)", locationData.OpenTime, locationData.CloseTime)
+	case "blackout_date":
+		return "Tanggal event berada pada periode blackout lokasi"
+	case "event_date_conflict":
+		return "Tanggal event bentrok dengan event lain"
+	}
+	return ""
+}
+
+// checkAvailability mengecek slot dan menyiapkan data detail (bentrokan, blackout dan saran slot lain)
+func checkAvailability(s *service, ctx *abstraction.Context, excludeId int, locationData *model.LocationEntityModel, start time.Time, end time.Time, eventType *model.EventTypeEntityModel) (string, map[string]interface{}, error) {
+	searchRange := time.Duration(slotSearchDays+1) * 24 * time.Hour
+	schedule, err := loadBookingSchedule(s, ctx, locationData, excludeId, start.Add(-searchRange), end.Add(searchRange))
+	if err != nil {
+		return "", nil, err
+	}
+
+	reason, conflicts, blackouts := schedule.check(start, end, eventType)
+	if reason == "" {
+		return "", nil, nil
+	}
+
+	var (
+		conflictIds []int
+		conflictRes []map[string]interface{}
+		blackoutRes []map[string]interface{}
+	)
+	for _, v := range conflicts {
+		conflictIds = append(conflictIds, v.ID)
+		conflictRes = append(conflictRes, map[string]interface{}{
+			"id":               v.ID,
+			"event_name":       v.EventName,
+			"event_location":   v.EventLocation,
+			"event_date_start": general.FormatWithZWithoutChangingTime(v.EventDateStart),
+			"event_date_end":   general.FormatWithZWithoutChangingTime(v.EventDateEnd),
+		})
+	}
+	for _, v := range blackouts {
+		blackoutRes = append(blackoutRes, map[string]interface{}{
+			"id":         v.ID,
+			"date_start": general.FormatWithZWithoutChangingTime(v.DateStart),
+			"date_end":   general.FormatWithZWithoutChangingTime(v.DateEnd),
+			"reason":     v.Reason,
+		})
+	}
+	return reason, map[string]interface{}{
+		"conflict_ids": conflictIds,
+		"conflicts":    conflictRes,
+		"blackouts":    blackoutRes,
+		"suggestions":  schedule.suggest(start, end, eventType),
+	}, nil
+}
+
+// checkBookingConflict memastikan slot di lokasi tersebut bisa dipakai, jika tidak error berisi detail dan saran slot
+func checkBookingConflict(s *service, ctx *abstraction.Context, excludeId int, locationData *model.LocationEntityModel, start time.Time, end time.Time, eventType *model.EventTypeEntityModel) error {
+	reason, detail, err := checkAvailability(s, ctx, excludeId, locationData, start, end, eventType)
+	if err != nil {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if reason == "" {
+		return nil
+	}
+	return response.ErrorBuilderWithData(
+		http.StatusBadRequest,
+		errors.New(reason),
+		slotReasonMessage(reason, locationData),
+		detail,
+	)
+}
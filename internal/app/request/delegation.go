@@ -0,0 +1,52 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+	"slices"
+)
+
+// activeDelegators mengambil user yang sedang diwakili oleh user login
+func activeDelegators(s *service, ctx *abstraction.Context) ([]*model.UserEntityModel, error) {
+	delegations, err := s.DelegationRepository.FindActiveByDelegateId(ctx, ctx.Auth.ID, *general.NowWithLocation())
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	var res []*model.UserEntityModel
+	for _, v := range delegations {
+		user := v.User
+		res = append(res, &user)
+	}
+	return res, nil
+}
+
+// resolveDelegate mengganti user yang sedang cuti dengan delegasinya
+func resolveDelegate(s *service, ctx *abstraction.Context, user *model.UserEntityModel) (*model.UserEntityModel, error) {
+	delegation, err := s.DelegationRepository.FindActiveByUserId(ctx, user.ID, *general.NowWithLocation())
+	if err != nil && err.Error() != "record not found" {
+		return nil, err
+	}
+	if delegation == nil {
+		return user, nil
+	}
+	return &delegation.Delegate, nil
+}
+
+// roleOnBehalfOf mengecek apakah user login boleh bertindak dengan salah satu roles, langsung atau lewat delegasi.
+// onBehalfOf berisi id user yang diwakili jika hak tersebut hanya didapat dari delegasi
+func roleOnBehalfOf(s *service, ctx *abstraction.Context, roles []int) (permitted bool, onBehalfOf *int, err error) {
+	if slices.Contains(roles, ctx.Auth.RoleID) {
+		return true, nil, nil
+	}
+	delegators, err := activeDelegators(s, ctx)
+	if err != nil {
+		return false, nil, err
+	}
+	for _, v := range delegators {
+		if slices.Contains(roles, v.RoleId) {
+			return true, &v.ID, nil
+		}
+	}
+	return false, nil, nil
+}
@@ -0,0 +1,76 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"testing"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type fakeDelegationRepository struct {
+	repository.Delegation
+	delegations []*model.DelegationEntityModel
+}
+
+func (r *fakeDelegationRepository) FindActiveByDelegateId(ctx *abstraction.Context, delegate_id int, at time.Time) ([]*model.DelegationEntityModel, error) {
+	return r.delegations, nil
+}
+
+type fakeStatusHistoryRepository struct {
+	repository.RequestStatusHistory
+	created []*model.RequestStatusHistoryEntityModel
+}
+
+func (r *fakeStatusHistoryRepository) Create(ctx *abstraction.Context, data *model.RequestStatusHistoryEntityModel) *gorm.DB {
+	r.created = append(r.created, data)
+	return &gorm.DB{}
+}
+
+type fakeRequestRepository struct {
+	repository.Request
+}
+
+func (r *fakeRequestRepository) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return &gorm.DB{}
+}
+
+func TestDelegatedTransitionRecordsOnBehalfOf(t *testing.T) {
+	const (
+		delegatorId = 10
+		delegateId  = 20
+	)
+	history := &fakeStatusHistoryRepository{}
+	s := &service{
+		RequestRepository:              &fakeRequestRepository{},
+		RequestStatusHistoryRepository: history,
+		DelegationRepository: &fakeDelegationRepository{
+			delegations: []*model.DelegationEntityModel{{
+				DelegationEntity: model.DelegationEntity{UserId: delegatorId, DelegateId: delegateId},
+				User:             model.UserEntityModel{ID: delegatorId, UserEntity: model.UserEntity{RoleId: constant.ROLE_ID_BM}},
+			}},
+		},
+	}
+	ctx := &abstraction.Context{Auth: &abstraction.AuthContext{ID: delegateId, RoleID: constant.ROLE_ID_ADMIN}}
+	requestData := &model.RequestEntityModel{ID: 1, RequestEntity: model.RequestEntity{StatusId: constant.STATUS_ID_PENGAJUAN}}
+
+	onBehalfOf, err := validateStatusTransition(s, ctx, requestData, constant.STATUS_ID_VALIDASI, "")
+	if err != nil {
+		t.Fatalf("delegated transition rejected: %v", err)
+	}
+	from := requestData.StatusId
+	if err = createStatusHistory(s, ctx, requestData.ID, &from, constant.STATUS_ID_VALIDASI, "", onBehalfOf); err != nil {
+		t.Fatalf("create status history: %v", err)
+	}
+
+	if len(history.created) != 1 {
+		t.Fatalf("expected 1 history row, got %d", len(history.created))
+	}
+	got := history.created[0].OnBehalfOf
+	if got == nil || *got != delegatorId {
+		t.Fatalf("expected on_behalf_of %d, got %v", delegatorId, got)
+	}
+}
@@ -0,0 +1,308 @@
+package evaluation
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"bm_binus/pkg/ws"
+	"errors"
+	"math"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Create(ctx *abstraction.Context, payload *dto.RequestEvaluationCreateRequest) (map[string]interface{}, error)
+	FindByRequestId(ctx *abstraction.Context, payload *dto.RequestEvaluationFindByRequestIDRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.RequestEvaluationUpdateRequest) (map[string]interface{}, error)
+	Summary(ctx *abstraction.Context) (map[string]interface{}, error)
+}
+
+type service struct {
+	RequestEvaluationRepository repository.RequestEvaluation
+	RequestRepository           repository.Request
+	LocationRepository          repository.Location
+	EventTypeRepository         repository.EventType
+	NotificationRepository      repository.Notification
+	DelegationRepository        repository.Delegation
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		RequestEvaluationRepository: f.RequestEvaluationRepository,
+		RequestRepository:           f.RequestRepository,
+		LocationRepository:          f.LocationRepository,
+		EventTypeRepository:         f.EventTypeRepository,
+		NotificationRepository:      f.NotificationRepository,
+		DelegationRepository:        f.DelegationRepository,
+
+		DB: f.Db,
+	}
+}
+
+// SendNotif: lihat repository.SendNotification, mengembalikan id penerima akhir (delegasi jika user sedang cuti)
+func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) (int, error) {
+	return repository.SendNotification(ctx, s.NotificationRepository, s.DelegationRepository, title, message, userId, requestId)
+}
+
+func roundRating(v float64) float64 {
+	return math.Round(v*100) / 100
+}
+
+// SummaryResponse: bentuk response rata-rata evaluasi, dipakai juga oleh detail dan export request
+func SummaryResponse(v *model.RequestEvaluationSummary) map[string]interface{} {
+	if v == nil {
+		v = &model.RequestEvaluationSummary{}
+	}
+	return map[string]interface{}{
+		"count":             v.Count,
+		"rating_location":   roundRating(v.RatingLocation),
+		"rating_facility":   roundRating(v.RatingFacility),
+		"rating_service":    roundRating(v.RatingService),
+		"rating_overall":    roundRating(v.RatingOverall),
+		"actual_attendance": roundRating(v.ActualAttendance),
+	}
+}
+
+// findFinishedRequest: evaluasi hanya bisa diisi setelah request selesai
+func findFinishedRequest(s *service, ctx *abstraction.Context, requestId int) (*model.RequestEntityModel, error) {
+	requestData, err := s.RequestRepository.FindById(ctx, requestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+	if requestData.StatusId != constant.STATUS_ID_SELESAI {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not finished yet")
+	}
+	return requestData, nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestEvaluationCreateRequest) (map[string]interface{}, error) {
+	var sendNotifTo []int
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := findFinishedRequest(s, ctx, payload.RequestId)
+		if err != nil {
+			return err
+		}
+		if payload.ActualAttendance != nil && requestData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "only the owner of the request can report actual attendance")
+		}
+
+		existData, err := s.RequestEvaluationRepository.FindByRequestIdAndUserId(ctx, payload.RequestId, ctx.Auth.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if existData != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "evaluation already submitted")
+		}
+
+		modelEvaluation := &model.RequestEvaluationEntityModel{
+			Context: ctx,
+			RequestEvaluationEntity: model.RequestEvaluationEntity{
+				RequestId:        payload.RequestId,
+				UserId:           ctx.Auth.ID,
+				RatingLocation:   payload.RatingLocation,
+				RatingFacility:   payload.RatingFacility,
+				RatingService:    payload.RatingService,
+				RatingOverall:    payload.RatingOverall,
+				Comment:          payload.Comment,
+				ActualAttendance: payload.ActualAttendance,
+				IsDelete:         false,
+			},
+		}
+		if err = s.RequestEvaluationRepository.Create(ctx, modelEvaluation).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		if requestData.UserId != ctx.Auth.ID {
+			recipient, err := SendNotif(s, ctx, "Evaluasi baru!", requestData.EventName, requestData.UserId, requestData.ID)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			sendNotifTo = append(sendNotifTo, recipient)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range sendNotifTo {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) FindByRequestId(ctx *abstraction.Context, payload *dto.RequestEvaluationFindByRequestIDRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	requestData, err := s.RequestRepository.FindById(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	data, err := s.RequestEvaluationRepository.FindByRequestId(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	summary, err := s.RequestEvaluationRepository.Summarize(ctx, "id", &payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range data {
+		res = append(res, map[string]interface{}{
+			"id":                v.ID,
+			"request_id":        v.RequestId,
+			"rating_location":   v.RatingLocation,
+			"rating_facility":   v.RatingFacility,
+			"rating_service":    v.RatingService,
+			"rating_overall":    v.RatingOverall,
+			"comment":           v.Comment,
+			"actual_attendance": v.ActualAttendance,
+			"is_owner":          v.UserId == requestData.UserId,
+			"created_at":        general.FormatWithZWithoutChangingTime(v.CreatedAt),
+			"user": map[string]interface{}{
+				"id":   v.User.ID,
+				"name": v.User.Name,
+			},
+		})
+	}
+
+	var summaryData *model.RequestEvaluationSummary = nil
+	if len(summary) > 0 {
+		summaryData = summary[0]
+	}
+	return map[string]interface{}{
+		"summary": SummaryResponse(summaryData),
+		"data":    res,
+	}, nil
+}
+
+// Update: evaluasi hanya bisa diubah oleh pengisinya sendiri
+func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestEvaluationUpdateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		evaluationData, err := s.RequestEvaluationRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if evaluationData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "evaluation not found")
+		}
+		if evaluationData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		requestData, err := findFinishedRequest(s, ctx, evaluationData.RequestId)
+		if err != nil {
+			return err
+		}
+		if payload.ActualAttendance != nil && requestData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "only the owner of the request can report actual attendance")
+		}
+
+		columns := map[string]interface{}{}
+		if payload.RatingLocation != nil {
+			columns["rating_location"] = *payload.RatingLocation
+		}
+		if payload.RatingFacility != nil {
+			columns["rating_facility"] = *payload.RatingFacility
+		}
+		if payload.RatingService != nil {
+			columns["rating_service"] = *payload.RatingService
+		}
+		if payload.RatingOverall != nil {
+			columns["rating_overall"] = *payload.RatingOverall
+		}
+		if payload.Comment != nil {
+			columns["comment"] = *payload.Comment
+		}
+		if payload.ActualAttendance != nil {
+			columns["actual_attendance"] = *payload.ActualAttendance
+		}
+		if len(columns) > 0 {
+			if err = s.RequestEvaluationRepository.UpdateColumns(ctx, payload.ID, columns).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
+
+// Summary merangkum rata-rata evaluasi per lokasi dan per event type
+func (s *service) Summary(ctx *abstraction.Context) (map[string]interface{}, error) {
+	byLocation, err := s.RequestEvaluationRepository.Summarize(ctx, "location_id", nil)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	byEventType, err := s.RequestEvaluationRepository.Summarize(ctx, "event_type_id", nil)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	dataLocation, err := s.LocationRepository.Find(ctx, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	dataEventType, err := s.EventTypeRepository.Find(ctx, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	locationMap := make(map[int]*model.RequestEvaluationSummary)
+	for _, v := range byLocation {
+		locationMap[v.GroupID] = v
+	}
+	var resLocation []map[string]interface{}
+	for _, v := range dataLocation {
+		item := SummaryResponse(locationMap[v.ID])
+		item["location"] = map[string]interface{}{
+			"id":   v.ID,
+			"name": v.Name,
+		}
+		resLocation = append(resLocation, item)
+	}
+
+	eventTypeMap := make(map[int]*model.RequestEvaluationSummary)
+	for _, v := range byEventType {
+		eventTypeMap[v.GroupID] = v
+	}
+	var resEventType []map[string]interface{}
+	for _, v := range dataEventType {
+		item := SummaryResponse(eventTypeMap[v.ID])
+		item["event_type"] = map[string]interface{}{
+			"id":   v.ID,
+			"name": v.Name,
+		}
+		resEventType = append(resEventType, item)
+	}
+
+	return map[string]interface{}{
+		"by_location":   resLocation,
+		"by_event_type": resEventType,
+	}, nil
+}
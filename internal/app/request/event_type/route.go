@@ -11,4 +11,10 @@ func (h *Handler) Route(v *echo.Group) {
 	v.POST("", h.Create, middleware.Authentication)
 	v.DELETE("/:id", h.Delete, middleware.Authentication)
 	v.PUT("/:id", h.Update, middleware.Authentication)
+	v.GET("/:id/approval-step", h.FindApprovalStep, middleware.Authentication)
+	v.PUT("/:id/approval-step", h.UpdateApprovalStep, middleware.Authentication)
+	v.GET("/:id/checklist", h.FindChecklist, middleware.Authentication)
+	v.PUT("/:id/checklist", h.UpdateChecklist, middleware.Authentication)
+	v.GET("/trash", h.FindTrash, middleware.Authentication)
+	v.PATCH("/:id/restore", h.Restore, middleware.Authentication)
 }
@@ -7,10 +7,12 @@ import (
 	"bm_binus/internal/model"
 	"bm_binus/internal/repository"
 	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
 	"bm_binus/pkg/util/response"
 	"bm_binus/pkg/util/trxmanager"
 	"errors"
 	"net/http"
+	"strings"
 
 	"gorm.io/gorm"
 )
@@ -20,17 +22,31 @@ type Service interface {
 	Create(ctx *abstraction.Context, payload *dto.EventTypeCreateRequest) (map[string]interface{}, error)
 	Delete(ctx *abstraction.Context, payload *dto.EventTypeDeleteByIDRequest) (map[string]interface{}, error)
 	Update(ctx *abstraction.Context, payload *dto.EventTypeUpdateRequest) (map[string]interface{}, error)
+	FindApprovalStep(ctx *abstraction.Context, payload *dto.EventTypeFindApprovalStepRequest) (map[string]interface{}, error)
+	UpdateApprovalStep(ctx *abstraction.Context, payload *dto.EventTypeApprovalStepRequest) (map[string]interface{}, error)
+	FindChecklist(ctx *abstraction.Context, payload *dto.EventTypeFindChecklistRequest) (map[string]interface{}, error)
+	UpdateChecklist(ctx *abstraction.Context, payload *dto.EventTypeChecklistRequest) (map[string]interface{}, error)
+	FindTrash(ctx *abstraction.Context) (map[string]interface{}, error)
+	Restore(ctx *abstraction.Context, payload *dto.EventTypeRestoreRequest) (map[string]interface{}, error)
 }
 
 type service struct {
-	EventTypeRepository repository.EventType
+	EventTypeRepository         repository.EventType
+	ApprovalStepRepository      repository.ApprovalStep
+	ChecklistTemplateRepository repository.ChecklistTemplate
+	RoleRepository              repository.Role
+	UserRepository              repository.User
 
 	DB *gorm.DB
 }
 
 func NewService(f *factory.Factory) Service {
 	return &service{
-		EventTypeRepository: f.EventTypeRepository,
+		EventTypeRepository:         f.EventTypeRepository,
+		ApprovalStepRepository:      f.ApprovalStepRepository,
+		ChecklistTemplateRepository: f.ChecklistTemplateRepository,
+		RoleRepository:              f.RoleRepository,
+		UserRepository:              f.UserRepository,
 
 		DB: f.Db,
 	}
@@ -53,9 +69,13 @@ func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error)
 
 	for _, v := range data {
 		res = append(res, map[string]interface{}{
-			"id":       v.ID,
-			"name":     v.Name,
-			"priority": v.Priority,
+			"id":               v.ID,
+			"name":             v.Name,
+			"priority":         v.Priority,
+			"setup_minutes":    v.SetupMinutes,
+			"teardown_minutes": v.TeardownMinutes,
+			"assign_strategy":  v.AssignStrategy,
+			"version":          v.Version,
 		})
 		priorityCount[v.Priority]++
 		if priorityCount[v.Priority] > 1 {
@@ -83,12 +103,20 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.EventTypeCreateR
 			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
 		}
 
+		assignStrategy := payload.AssignStrategy
+		if assignStrategy == "" {
+			assignStrategy = constant.ASSIGN_STRATEGY_MANUAL
+		}
+
 		modelEventType := &model.EventTypeEntityModel{
 			Context: ctx,
 			EventTypeEntity: model.EventTypeEntity{
-				Name:     payload.Name,
-				Priority: payload.Priority,
-				IsDelete: false,
+				Name:            payload.Name,
+				Priority:        payload.Priority,
+				SetupMinutes:    payload.SetupMinutes,
+				TeardownMinutes: payload.TeardownMinutes,
+				AssignStrategy:  assignStrategy,
+				IsDelete:        false,
 			},
 		}
 		if err := s.EventTypeRepository.Create(ctx, modelEventType).Error; err != nil {
@@ -122,9 +150,14 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.EventTypeDeleteB
 		newEventTypeData.Context = ctx
 		newEventTypeData.ID = eventTypeData.ID
 		newEventTypeData.IsDelete = true
+		newEventTypeData.DeletedAt = general.NowWithLocation()
 
-		if err = s.EventTypeRepository.Update(ctx, newEventTypeData).Error; err != nil {
-			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		result := s.EventTypeRepository.UpdateWithVersion(ctx, newEventTypeData, eventTypeData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
 		}
 
 		return nil
@@ -137,6 +170,7 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.EventTypeDeleteB
 }
 
 func (s *service) Update(ctx *abstraction.Context, payload *dto.EventTypeUpdateRequest) (map[string]interface{}, error) {
+	var newVersion int
 	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
 		eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.ID)
 		if err != nil && err.Error() != "record not found" {
@@ -146,6 +180,22 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.EventTypeUpdateR
 			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
 		}
 
+		version, err := general.ProcessVersion(ctx, payload.Version)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), err.Error())
+		}
+		if version != nil && *version != eventTypeData.Version {
+			return response.ErrorVersionConflict(map[string]interface{}{
+				"id":               eventTypeData.ID,
+				"name":             eventTypeData.Name,
+				"priority":         eventTypeData.Priority,
+				"setup_minutes":    eventTypeData.SetupMinutes,
+				"teardown_minutes": eventTypeData.TeardownMinutes,
+				"assign_strategy":  eventTypeData.AssignStrategy,
+				"version":          eventTypeData.Version,
+			})
+		}
+
 		newEventTypeData := new(model.EventTypeEntityModel)
 		newEventTypeData.Context = ctx
 		newEventTypeData.ID = payload.ID
@@ -155,10 +205,140 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.EventTypeUpdateR
 		if payload.Priority != nil {
 			newEventTypeData.Priority = *payload.Priority
 		}
+		if payload.AssignStrategy != nil {
+			newEventTypeData.AssignStrategy = *payload.AssignStrategy
+		}
+
+		result := s.EventTypeRepository.UpdateWithVersion(ctx, newEventTypeData, eventTypeData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+		newVersion = newEventTypeData.Version
+
+		// buffer 0 tidak ikut terupdate lewat struct, jadi diset eksplisit
+		bufferColumns := map[string]interface{}{}
+		if payload.SetupMinutes != nil {
+			bufferColumns["setup_minutes"] = *payload.SetupMinutes
+		}
+		if payload.TeardownMinutes != nil {
+			bufferColumns["teardown_minutes"] = *payload.TeardownMinutes
+		}
+		if len(bufferColumns) > 0 {
+			if err = s.EventTypeRepository.UpdateColumns(ctx, payload.ID, bufferColumns).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+		"version": newVersion,
+	}, nil
+}
+
+func (s *service) FindApprovalStep(ctx *abstraction.Context, payload *dto.EventTypeFindApprovalStepRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if eventTypeData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+	}
 
-		if err = s.EventTypeRepository.Update(ctx, newEventTypeData).Error; err != nil {
+	data, err := s.ApprovalStepRepository.FindByEventTypeId(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var roleRes, userRes map[string]interface{}
+		if v.Role != nil {
+			roleRes = map[string]interface{}{
+				"id":   v.Role.ID,
+				"name": v.Role.Name,
+			}
+		}
+		if v.User != nil {
+			userRes = map[string]interface{}{
+				"id":   v.User.ID,
+				"name": v.User.Name,
+			}
+		}
+		res = append(res, map[string]interface{}{
+			"id":         v.ID,
+			"step_order": v.StepOrder,
+			"role":       roleRes,
+			"user":       userRes,
+		})
+	}
+
+	return map[string]interface{}{
+		"data": res,
+	}, nil
+}
+
+// UpdateApprovalStep mengganti seluruh rantai persetujuan, request yang sudah di validasi tetap memakai rantai lamanya
+func (s *service) UpdateApprovalStep(ctx *abstraction.Context, payload *dto.EventTypeApprovalStepRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
+		if eventTypeData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+		}
+
+		if err = s.ApprovalStepRepository.DeleteByEventTypeId(ctx, payload.ID).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		for _, v := range payload.Steps {
+			if (v.RoleId == nil) == (v.UserId == nil) {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "each approval step must have either role_id or user_id")
+			}
+			if v.RoleId != nil {
+				roleData, err := s.RoleRepository.FindById(ctx, *v.RoleId)
+				if err != nil && err.Error() != "record not found" {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				if roleData == nil {
+					return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "role not found")
+				}
+			}
+			if v.UserId != nil {
+				userData, err := s.UserRepository.FindById(ctx, *v.UserId)
+				if err != nil && err.Error() != "record not found" {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				if userData == nil {
+					return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "user not found")
+				}
+			}
+
+			modelStep := &model.ApprovalStepEntityModel{
+				Context: ctx,
+				ApprovalStepEntity: model.ApprovalStepEntity{
+					EventTypeId: payload.ID,
+					StepOrder:   v.StepOrder,
+					RoleId:      v.RoleId,
+					UserId:      v.UserId,
+					IsDelete:    false,
+				},
+			}
+			if err = s.ApprovalStepRepository.Create(ctx, modelStep).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
 
 		return nil
 	}); err != nil {
@@ -168,3 +348,159 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.EventTypeUpdateR
 		"message": "success update!",
 	}, nil
 }
+
+func (s *service) FindChecklist(ctx *abstraction.Context, payload *dto.EventTypeFindChecklistRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if eventTypeData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+	}
+
+	data, err := s.ChecklistTemplateRepository.FindByEventTypeId(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		res = append(res, map[string]interface{}{
+			"id":              v.ID,
+			"title":           v.Title,
+			"is_required":     v.IsRequired,
+			"due_days_before": v.DueDaysBefore,
+		})
+	}
+
+	return map[string]interface{}{
+		"data": res,
+	}, nil
+}
+
+// UpdateChecklist mengganti seluruh template checklist, request yang sudah di proses tetap memakai tugas yang sudah disalin
+func (s *service) UpdateChecklist(ctx *abstraction.Context, payload *dto.EventTypeChecklistRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if eventTypeData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+		}
+
+		if err = s.ChecklistTemplateRepository.DeleteByEventTypeId(ctx, payload.ID).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		for _, v := range payload.Tasks {
+			modelTemplate := &model.ChecklistTemplateEntityModel{
+				Context: ctx,
+				ChecklistTemplateEntity: model.ChecklistTemplateEntity{
+					EventTypeId:   payload.ID,
+					Title:         v.Title,
+					IsRequired:    v.IsRequired,
+					DueDaysBefore: v.DueDaysBefore,
+					IsDelete:      false,
+				},
+			}
+			if err = s.ChecklistTemplateRepository.Create(ctx, modelTemplate).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
+
+func (s *service) FindTrash(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+	}
+
+	data, err := s.EventTypeRepository.FindTrash(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.EventTypeRepository.CountTrash(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var deletedAt interface{} = nil
+		if v.DeletedAt != nil {
+			deletedAt = general.FormatWithZWithoutChangingTime(*v.DeletedAt)
+		}
+		res = append(res, map[string]interface{}{
+			"id":         v.ID,
+			"name":       v.Name,
+			"priority":   v.Priority,
+			"version":    v.Version,
+			"deleted_at": deletedAt,
+		})
+	}
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+// Restore: prioritas event type yang dikembalikan bisa bentrok dengan event type aktif, jadi info duplikat ikut dikembalikan
+func (s *service) Restore(ctx *abstraction.Context, payload *dto.EventTypeRestoreRequest) (map[string]interface{}, error) {
+	var duplicatePriority bool
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		eventTypeData, err := s.EventTypeRepository.FindDeletedById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if eventTypeData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found in trash")
+		}
+
+		activeData, err := s.EventTypeRepository.Find(ctx, true)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		for _, v := range activeData {
+			if strings.EqualFold(strings.TrimSpace(v.Name), strings.TrimSpace(eventTypeData.Name)) {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type name already used by another event type")
+			}
+			if v.Priority == eventTypeData.Priority {
+				duplicatePriority = true
+			}
+		}
+
+		if err = s.EventTypeRepository.UpdateColumns(ctx, payload.ID, map[string]interface{}{
+			"is_delete":  false,
+			"deleted_at": nil,
+			"version":    eventTypeData.Version + 1,
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	res := map[string]interface{}{
+		"message": "success restore!",
+	}
+	if duplicatePriority {
+		res["info"] = "Terdapat nilai prioritas yang duplikat, segera perbaiki!"
+	}
+	return res, nil
+}
@@ -27,6 +27,8 @@ type Service interface {
 	FindByRequestId(ctx *abstraction.Context, payload *dto.FileFindByRequestIDRequest) (map[string]interface{}, error)
 	Delete(ctx *abstraction.Context, payload *dto.FileDeleteByIDRequest) (map[string]interface{}, error)
 	Update(ctx *abstraction.Context, payload *dto.FileUpdateRequest) (map[string]interface{}, error)
+	FindTrash(ctx *abstraction.Context, payload *dto.FileFindTrashRequest) (map[string]interface{}, error)
+	Restore(ctx *abstraction.Context, payload *dto.FileRestoreRequest) (map[string]interface{}, error)
 }
 
 type service struct {
@@ -34,6 +36,7 @@ type service struct {
 	RequestRepository      repository.Request
 	NotificationRepository repository.Notification
 	UserRepository         repository.User
+	DelegationRepository   repository.Delegation
 
 	DB     *gorm.DB
 	sDrive *drive.Service
@@ -46,6 +49,7 @@ func NewService(f *factory.Factory) Service {
 		RequestRepository:      f.RequestRepository,
 		NotificationRepository: f.NotificationRepository,
 		UserRepository:         f.UserRepository,
+		DelegationRepository:   f.DelegationRepository,
 
 		DB:     f.Db,
 		sDrive: f.GDrive.Service,
@@ -53,21 +57,9 @@ func NewService(f *factory.Factory) Service {
 	}
 }
 
-func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) error {
-	modelNotification := &model.NotificationEntityModel{
-		Context: ctx,
-		NotificationEntity: model.NotificationEntity{
-			Title:     title,
-			Message:   message,
-			IsRead:    false,
-			UserId:    userId,
-			RequestId: requestId,
-		},
-	}
-	if err := s.NotificationRepository.Create(ctx, modelNotification).Error; err != nil {
-		return err
-	}
-	return nil
+// SendNotif: lihat repository.SendNotification, mengembalikan id penerima akhir (delegasi jika user sedang cuti)
+func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) (int, error) {
+	return repository.SendNotification(ctx, s.NotificationRepository, s.DelegationRepository, title, message, userId, requestId)
 }
 
 func (s *service) Create(ctx *abstraction.Context, payload *dto.FileCreateRequest) (map[string]interface{}, error) {
@@ -131,6 +123,11 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.FileCreateReques
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
 
+		// draft belum diajukan ke BM, jadi tidak ada notifikasi
+		if requestData.StatusId == constant.STATUS_ID_DRAFT {
+			return nil
+		}
+
 		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
 		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
@@ -146,7 +143,7 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.FileCreateReques
 		}
 		switch ctx.Auth.RoleID {
 		case constant.ROLE_ID_STAF:
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 			if slices.Contains(statusesForAdmin, requestData.StatusId) {
 				addIDs(userAdmin)
 			}
@@ -157,11 +154,11 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.FileCreateReques
 			}
 		case constant.ROLE_ID_ADMIN:
 			sendNotifTo = append(sendNotifTo, requestData.UserId)
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 		}
 
-		for _, v := range sendNotifTo {
-			err = SendNotif(s, ctx, "Berkas Baru!", general.FormatNamesFromArray(allFileName), v, requestData.ID)
+		for i, v := range sendNotifTo {
+			sendNotifTo[i], err = SendNotif(s, ctx, "Berkas Baru!", general.FormatNamesFromArray(allFileName), v, requestData.ID)
 			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
@@ -266,6 +263,7 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.FileDeleteByIDRe
 		newFileData.Context = ctx
 		newFileData.ID = fileData.ID
 		newFileData.IsDelete = true
+		newFileData.DeletedAt = general.NowWithLocation()
 		if err = s.FileRepository.Update(ctx, newFileData).Error; err != nil {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
@@ -278,6 +276,11 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.FileDeleteByIDRe
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
 
+		// draft belum diajukan ke BM, jadi tidak ada notifikasi
+		if requestData.StatusId == constant.STATUS_ID_DRAFT {
+			return nil
+		}
+
 		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
 		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
@@ -293,7 +296,7 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.FileDeleteByIDRe
 		}
 		switch ctx.Auth.RoleID {
 		case constant.ROLE_ID_STAF:
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 			if slices.Contains(statusesForAdmin, requestData.StatusId) {
 				addIDs(userAdmin)
 			}
@@ -304,11 +307,11 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.FileDeleteByIDRe
 			}
 		case constant.ROLE_ID_ADMIN:
 			sendNotifTo = append(sendNotifTo, requestData.UserId)
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 		}
 
-		for _, v := range sendNotifTo {
-			err = SendNotif(s, ctx, "Berkas dihapus!", fileNameDelete, v, requestData.ID)
+		for i, v := range sendNotifTo {
+			sendNotifTo[i], err = SendNotif(s, ctx, "Berkas dihapus!", fileNameDelete, v, requestData.ID)
 			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
@@ -365,3 +368,89 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.FileUpdateReques
 		"message": "success update!",
 	}, nil
 }
+
+func (s *service) FindTrash(ctx *abstraction.Context, payload *dto.FileFindTrashRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	requestData, err := s.RequestRepository.FindById(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	data, err := s.FileRepository.FindTrashByRequestId(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var deletedAt interface{} = nil
+		if v.DeletedAt != nil {
+			deletedAt = general.FormatWithZWithoutChangingTime(*v.DeletedAt)
+		}
+		res = append(res, map[string]interface{}{
+			"id":         v.ID,
+			"request_id": v.RequestId,
+			"file_name":  v.FileName,
+			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
+			"deleted_at": deletedAt,
+		})
+	}
+
+	return map[string]interface{}{
+		"count": len(data),
+		"data":  res,
+	}, nil
+}
+
+// Restore: file dikembalikan oleh pemilik request atau BM/admin, berkas di drive harus masih ada
+func (s *service) Restore(ctx *abstraction.Context, payload *dto.FileRestoreRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		fileData, err := s.FileRepository.FindDeletedById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if fileData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "file not found in trash")
+		}
+
+		requestData, err := s.RequestRepository.FindById(ctx, fileData.RequestId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request of this file is deleted, restore the request first")
+		}
+		if requestData.UserId != ctx.Auth.ID && ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		if _, err = gdrive.GetFile(s.sDrive, fileData.File); err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "file is no longer available in drive")
+		}
+
+		if err = s.FileRepository.UpdateColumns(ctx, payload.ID, map[string]interface{}{
+			"is_delete":  false,
+			"deleted_at": nil,
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		newRequestData := new(model.RequestEntityModel)
+		newRequestData.Context = ctx
+		newRequestData.ID = fileData.RequestId
+		newRequestData.UpdatedAt = general.NowLocal()
+		if err = s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": "success restore!",
+	}, nil
+}
@@ -0,0 +1,113 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// expandRecurrence mengubah RRULE dan exdate menjadi daftar tanggal mulai setiap kejadian
+func expandRecurrence(rule string, exdate string, start time.Time) ([]time.Time, error) {
+	recurrenceRule, err := general.ParseRecurrenceRule(rule)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("invalid_recurrence"), "recurrence: "+err.Error())
+	}
+	exdates, err := general.ParseRecurrenceExdates(exdate)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("invalid_recurrence"), "recurrence: "+err.Error())
+	}
+	occurrenceStarts, err := recurrenceRule.Expand(start, exdates, constant.REQUEST_RECURRENCE_MAX)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("invalid_recurrence"), "recurrence: "+err.Error())
+	}
+	if len(occurrenceStarts) == 0 {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("invalid_recurrence"), "recurrence does not produce any occurrence")
+	}
+	return occurrenceStarts, nil
+}
+
+// occurrenceCheck: cek bentrok jadwal satu occurrence yang ditunda sampai semua occurrence series selesai digeser
+type occurrenceCheck struct {
+	id        int
+	location  *model.LocationEntityModel
+	start     time.Time
+	end       time.Time
+	eventType *model.EventTypeEntityModel
+}
+
+// updateFollowingOccurrences menerapkan perubahan ke kejadian berikutnya dalam seri,
+// tanggal digeser sebesar selisih perubahan pada kejadian yang diedit. Bentrok jadwal (termasuk pendingChecks
+// dari kejadian yang diedit) baru dicek setelah semua kejadian digeser, supaya kejadian yang sudah bergeser
+// tidak dianggap bentrok dengan jadwal lama kejadian berikutnya di seri yang sama
+func updateFollowingOccurrences(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel, newRequestData *model.RequestEntityModel, eventTypeData *model.EventTypeEntityModel, shiftStart time.Duration, shiftEnd time.Duration, pendingChecks []occurrenceCheck) error {
+	following, err := s.RequestRepository.FindBySeriesId(ctx, *requestData.SeriesId, requestData.SeriesIndex+1)
+	if err != nil && err.Error() != "record not found" {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range following {
+		newOccurrenceData := new(model.RequestEntityModel)
+		newOccurrenceData.Context = ctx
+		newOccurrenceData.ID = v.ID
+		newOccurrenceData.EventName = newRequestData.EventName
+		newOccurrenceData.Description = newRequestData.Description
+		newOccurrenceData.EventTypeId = newRequestData.EventTypeId
+		newOccurrenceData.CountParticipant = newRequestData.CountParticipant
+		newOccurrenceData.LocationId = newRequestData.LocationId
+		newOccurrenceData.EventLocation = newRequestData.EventLocation
+
+		checkStart := v.EventDateStart.Add(shiftStart)
+		checkEnd := v.EventDateEnd.Add(shiftEnd)
+		if shiftStart != 0 || shiftEnd != 0 {
+			newOccurrenceData.EventDateStart = checkStart
+			newOccurrenceData.EventDateEnd = checkEnd
+		}
+
+		occurrenceEventType := &v.EventType
+		if newRequestData.EventTypeId != 0 {
+			occurrenceEventType = eventTypeData
+		}
+		checkLocationId := v.LocationId
+		if newRequestData.LocationId != nil {
+			checkLocationId = newRequestData.LocationId
+		}
+		if checkLocationId != nil && !slices.Contains(nonBookingStatuses, v.StatusId) {
+			checkCountParticipant := v.CountParticipant
+			if newRequestData.CountParticipant != 0 {
+				checkCountParticipant = newRequestData.CountParticipant
+			}
+			locationData, err := findBookableLocation(s, ctx, *checkLocationId, checkCountParticipant)
+			if err != nil {
+				return err
+			}
+			pendingChecks = append(pendingChecks, occurrenceCheck{
+				id:        v.ID,
+				location:  locationData,
+				start:     checkStart,
+				end:       checkEnd,
+				eventType: occurrenceEventType,
+			})
+		}
+
+		result := s.RequestRepository.UpdateWithVersion(ctx, newOccurrenceData, v.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+	}
+
+	for _, v := range pendingChecks {
+		if err = checkBookingConflict(s, ctx, v.id, v.location, v.start, v.end, v.eventType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
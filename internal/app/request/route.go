@@ -8,14 +8,38 @@ import (
 
 func (h *handler) Route(v *echo.Group) {
 	v.POST("", h.Create, middleware.Authentication)
+	v.POST("/draft", h.CreateDraft, middleware.Authentication)
+	v.POST("/bulk", h.Bulk, middleware.Authentication)
+	v.POST("/:id/submit", h.Submit, middleware.Authentication)
 	v.GET("", h.Find, middleware.Authentication)
 	v.GET("/:id", h.FindById, middleware.Authentication)
 	v.PUT("/:id", h.Update, middleware.Authentication)
 	v.DELETE("/:id", h.Delete, middleware.Authentication)
 	v.GET("/export", h.Export, middleware.Authentication)
 	v.GET("/export/:id", h.ExportById, middleware.Authentication)
+	v.GET("/transition", h.FindTransition, middleware.Authentication)
+	v.GET("/:id/transition", h.FindTransitionById, middleware.Authentication)
+	v.GET("/:id/history", h.FindHistory, middleware.Authentication)
+	v.PATCH("/:id/reject", h.Reject, middleware.Authentication)
+	v.PATCH("/:id/cancel", h.Cancel, middleware.Authentication)
+	v.GET("/availability", h.Availability, middleware.Authentication)
+	v.GET("/series/:id", h.FindSeries, middleware.Authentication)
+	v.PATCH("/series/:id/status", h.UpdateSeriesStatus, middleware.Authentication)
+	v.POST("/:id/clone", h.Clone, middleware.Authentication)
+	v.POST("/template/:id/apply", h.ApplyTemplate, middleware.Authentication)
+	v.GET("/:id/approval", h.FindApproval, middleware.Authentication)
+	v.POST("/:id/approval", h.DecideApproval, middleware.Authentication)
+	v.PATCH("/:id/assign", h.Assign, middleware.Authentication)
+	v.GET("/:id/asset", h.FindAsset, middleware.Authentication)
+	v.PUT("/:id/asset", h.UpdateAsset, middleware.Authentication)
+	v.GET("/trash", h.FindTrash, middleware.Authentication)
+	v.PATCH("/:id/restore", h.Restore, middleware.Authentication)
 
 	h.EventTypeHandler.Route(v.Group("/event-type"))
 	h.CommentHandler.Route(v.Group("/comment"))
 	h.FileHandler.Route(v.Group("/file"))
+	h.TemplateHandler.Route(v.Group("/template"))
+	h.TaskHandler.Route(v.Group("/task"))
+	h.BudgetHandler.Route(v.Group("/budget"))
+	h.EvaluationHandler.Route(v.Group("/evaluation"))
 }
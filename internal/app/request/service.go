@@ -2,6 +2,7 @@ package request
 
 import (
 	"bm_binus/internal/abstraction"
+	"bm_binus/internal/app/request/evaluation"
 	"bm_binus/internal/dto"
 	"bm_binus/internal/factory"
 	"bm_binus/internal/model"
@@ -20,6 +21,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/go-redis/redis/v8"
@@ -38,16 +40,51 @@ type Service interface {
 	Delete(ctx *abstraction.Context, payload *dto.RequestDeleteByIDRequest) (map[string]interface{}, error)
 	Export(ctx *abstraction.Context, payload *dto.RequestExportRequest) (string, *bytes.Buffer, string, error)
 	ExportById(ctx *abstraction.Context, payload *dto.RequestExportByIDRequest) (string, *bytes.Buffer, string, error)
+	FindTransition(ctx *abstraction.Context) (map[string]interface{}, error)
+	FindTransitionById(ctx *abstraction.Context, payload *dto.RequestFindTransitionByIDRequest) (map[string]interface{}, error)
+	FindHistory(ctx *abstraction.Context, payload *dto.RequestFindHistoryByIDRequest) (map[string]interface{}, error)
+	Reject(ctx *abstraction.Context, payload *dto.RequestRejectRequest) (map[string]interface{}, error)
+	Cancel(ctx *abstraction.Context, payload *dto.RequestCancelRequest) (map[string]interface{}, error)
+	Availability(ctx *abstraction.Context, payload *dto.RequestAvailabilityRequest) (map[string]interface{}, error)
+	FindSeries(ctx *abstraction.Context, payload *dto.RequestFindSeriesByIDRequest) (map[string]interface{}, error)
+	UpdateSeriesStatus(ctx *abstraction.Context, payload *dto.RequestSeriesStatusRequest) (map[string]interface{}, error)
+	Bulk(ctx *abstraction.Context, payload *dto.RequestBulkRequest) (map[string]interface{}, error)
+	CreateDraft(ctx *abstraction.Context, payload *dto.RequestCreateDraftRequest) (map[string]interface{}, error)
+	Submit(ctx *abstraction.Context, payload *dto.RequestSubmitRequest) (map[string]interface{}, error)
+	Clone(ctx *abstraction.Context, payload *dto.RequestCloneRequest) (map[string]interface{}, error)
+	ApplyTemplate(ctx *abstraction.Context, payload *dto.RequestApplyTemplateRequest) (map[string]interface{}, error)
+	Assign(ctx *abstraction.Context, payload *dto.RequestAssignRequest) (map[string]interface{}, error)
+	FindApproval(ctx *abstraction.Context, payload *dto.RequestFindApprovalRequest) (map[string]interface{}, error)
+	DecideApproval(ctx *abstraction.Context, payload *dto.RequestApprovalDecisionRequest) (map[string]interface{}, error)
+	FindAsset(ctx *abstraction.Context, payload *dto.RequestFindAssetRequest) (map[string]interface{}, error)
+	UpdateAsset(ctx *abstraction.Context, payload *dto.RequestAssetRequest) (map[string]interface{}, error)
+	FindTrash(ctx *abstraction.Context) (map[string]interface{}, error)
+	Restore(ctx *abstraction.Context, payload *dto.RequestRestoreRequest) (map[string]interface{}, error)
 }
 
 type service struct {
-	RequestRepository      repository.Request
-	EventTypeRepository    repository.EventType
-	FileRepository         repository.File
-	NotificationRepository repository.Notification
-	UserRepository         repository.User
-	StatusRepository       repository.Status
-	CommentRepository      repository.Comment
+	RequestRepository              repository.Request
+	EventTypeRepository            repository.EventType
+	FileRepository                 repository.File
+	NotificationRepository         repository.Notification
+	UserRepository                 repository.User
+	StatusRepository               repository.Status
+	CommentRepository              repository.Comment
+	RequestStatusHistoryRepository repository.RequestStatusHistory
+	LocationRepository             repository.Location
+	BlackoutDateRepository         repository.BlackoutDate
+	RequestSeriesRepository        repository.RequestSeries
+	RequestTemplateRepository      repository.RequestTemplate
+	ApprovalStepRepository         repository.ApprovalStep
+	RequestApprovalRepository      repository.RequestApproval
+	DelegationRepository           repository.Delegation
+	ChecklistTemplateRepository    repository.ChecklistTemplate
+	RequestTaskRepository          repository.RequestTask
+	AssetRepository                repository.Asset
+	RequestAssetRepository         repository.RequestAsset
+	RequestBudgetRepository        repository.RequestBudget
+	RequestEvaluationRepository    repository.RequestEvaluation
+	AhpCriteriaProfileRepository   repository.AhpCriteriaProfile
 
 	DB      *gorm.DB
 	DbRedis *redis.Client
@@ -57,13 +94,28 @@ type service struct {
 
 func NewService(f *factory.Factory) Service {
 	return &service{
-		RequestRepository:      f.RequestRepository,
-		EventTypeRepository:    f.EventTypeRepository,
-		FileRepository:         f.FileRepository,
-		NotificationRepository: f.NotificationRepository,
-		UserRepository:         f.UserRepository,
-		StatusRepository:       f.StatusRepository,
-		CommentRepository:      f.CommentRepository,
+		RequestRepository:              f.RequestRepository,
+		EventTypeRepository:            f.EventTypeRepository,
+		FileRepository:                 f.FileRepository,
+		NotificationRepository:         f.NotificationRepository,
+		UserRepository:                 f.UserRepository,
+		StatusRepository:               f.StatusRepository,
+		CommentRepository:              f.CommentRepository,
+		RequestStatusHistoryRepository: f.RequestStatusHistoryRepository,
+		LocationRepository:             f.LocationRepository,
+		BlackoutDateRepository:         f.BlackoutDateRepository,
+		RequestSeriesRepository:        f.RequestSeriesRepository,
+		RequestTemplateRepository:      f.RequestTemplateRepository,
+		ApprovalStepRepository:         f.ApprovalStepRepository,
+		RequestApprovalRepository:      f.RequestApprovalRepository,
+		DelegationRepository:           f.DelegationRepository,
+		ChecklistTemplateRepository:    f.ChecklistTemplateRepository,
+		RequestTaskRepository:          f.RequestTaskRepository,
+		AssetRepository:                f.AssetRepository,
+		RequestAssetRepository:         f.RequestAssetRepository,
+		RequestBudgetRepository:        f.RequestBudgetRepository,
+		RequestEvaluationRepository:    f.RequestEvaluationRepository,
+		AhpCriteriaProfileRepository:   f.AhpCriteriaProfileRepository,
 
 		DB:      f.Db,
 		DbRedis: f.DbRedis,
@@ -72,27 +124,16 @@ func NewService(f *factory.Factory) Service {
 	}
 }
 
-func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) error {
-	modelNotification := &model.NotificationEntityModel{
-		Context: ctx,
-		NotificationEntity: model.NotificationEntity{
-			Title:     title,
-			Message:   message,
-			IsRead:    false,
-			UserId:    userId,
-			RequestId: requestId,
-		},
-	}
-	if err := s.NotificationRepository.Create(ctx, modelNotification).Error; err != nil {
-		return err
-	}
-	return nil
+// SendNotif: lihat repository.SendNotification, mengembalikan id penerima akhir (delegasi jika user sedang cuti)
+func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) (int, error) {
+	return repository.SendNotification(ctx, s.NotificationRepository, s.DelegationRepository, title, message, userId, requestId)
 }
 
 func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestCreateRequest) (map[string]interface{}, error) {
 	var (
 		allFileUploaded []string
 		sendNotifTo     []int
+		createdId       int
 	)
 	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
 		if ctx.Auth.RoleID != constant.ROLE_ID_STAF {
@@ -117,53 +158,116 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestCreateReq
 			)
 		}
 
-		allDataRequest, err := s.RequestRepository.Find(ctx, true)
+		eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.EventTypeId)
 		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
+		if eventTypeData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+		}
 
-		for _, v := range allDataRequest {
-			existStart := v.EventDateStart
-			existEnd := v.EventDateEnd
-
-			if parsedEventDateStart.Before(existEnd) &&
-				parsedEventDateEnd.After(existStart) {
-
-				return response.ErrorBuilder(
-					http.StatusBadRequest,
-					errors.New("event_date_conflict"),
-					"Tanggal event bentrok dengan event lain",
-				)
-			}
+		locationData, err := findBookableLocation(s, ctx, payload.LocationId, payload.CountParticipant)
+		if err != nil {
+			return err
 		}
 
-		eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.EventTypeId)
-		if err != nil && err.Error() != "record not found" {
+		// semua kejadian dalam satu seri ditangani assignee yang sama
+		assignee, err := pickAssignee(s, ctx, eventTypeData)
+		if err != nil {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
-		if eventTypeData == nil {
-			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+		var assigneeId *int = nil
+		if assignee != nil {
+			assigneeId = &assignee.ID
 		}
 
-		modelRequest := &model.RequestEntityModel{
-			Context: ctx,
-			RequestEntity: model.RequestEntity{
-				UserId:           ctx.Auth.ID,
-				EventName:        payload.EventName,
-				EventLocation:    payload.EventLocation,
-				EventDateStart:   parsedEventDateStart,
-				EventDateEnd:     parsedEventDateEnd,
-				Description:      payload.Description,
-				EventTypeId:      payload.EventTypeId,
-				CountParticipant: payload.CountParticipant,
-				StatusId:         constant.STATUS_ID_PENGAJUAN,
-				IsDelete:         false,
-			},
+		occurrenceStarts := []time.Time{parsedEventDateStart}
+		var seriesId *int = nil
+		if strings.TrimSpace(payload.Recurrence) != "" {
+			occurrenceStarts, err = expandRecurrence(payload.Recurrence, payload.RecurrenceExdate, parsedEventDateStart)
+			if err != nil {
+				return err
+			}
+			modelSeries := &model.RequestSeriesEntityModel{
+				Context: ctx,
+				RequestSeriesEntity: model.RequestSeriesEntity{
+					UserId:         ctx.Auth.ID,
+					RecurrenceRule: strings.TrimSpace(payload.Recurrence),
+					Exdates:        strings.TrimSpace(payload.RecurrenceExdate),
+				},
+			}
+			if err = s.RequestSeriesRepository.Create(ctx, modelSeries).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			seriesId = &modelSeries.ID
 		}
-		if err = s.RequestRepository.Create(ctx, modelRequest).Error; err != nil {
-			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+
+		var (
+			duration          = parsedEventDateEnd.Sub(parsedEventDateStart)
+			createdRequest    []*model.RequestEntityModel
+			failedOccurrences []map[string]interface{}
+		)
+		for i, occurrenceStart := range occurrenceStarts {
+			occurrenceEnd := occurrenceStart.Add(duration)
+			// request yang sudah dibuat dalam trx ikut terbaca, jadi bentrok antar kejadian juga terdeteksi
+			reason, detail, err := checkAvailability(s, ctx, 0, locationData, occurrenceStart, occurrenceEnd, eventTypeData)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if reason != "" {
+				if seriesId == nil {
+					return response.ErrorBuilderWithData(http.StatusBadRequest, errors.New(reason), slotReasonMessage(reason, locationData), detail)
+				}
+				detail["series_index"] = i + 1
+				detail["event_date_start"] = occurrenceStart.Format("2006-01-02 15:04:05")
+				detail["event_date_end"] = occurrenceEnd.Format("2006-01-02 15:04:05")
+				detail["reason"] = reason
+				failedOccurrences = append(failedOccurrences, detail)
+				continue
+			}
+
+			modelRequest := &model.RequestEntityModel{
+				Context: ctx,
+				RequestEntity: model.RequestEntity{
+					UserId:           ctx.Auth.ID,
+					EventName:        payload.EventName,
+					EventLocation:    locationData.Name,
+					LocationId:       &locationData.ID,
+					EventDateStart:   occurrenceStart,
+					EventDateEnd:     occurrenceEnd,
+					Description:      payload.Description,
+					EventTypeId:      payload.EventTypeId,
+					CountParticipant: payload.CountParticipant,
+					StatusId:         constant.STATUS_ID_PENGAJUAN,
+					SeriesId:         seriesId,
+					AssigneeId:       assigneeId,
+					IsDelete:         false,
+				},
+			}
+			if seriesId != nil {
+				modelRequest.SeriesIndex = i + 1
+			}
+			if err = s.RequestRepository.Create(ctx, modelRequest).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+
+			if err = createStatusHistory(s, ctx, modelRequest.ID, nil, modelRequest.StatusId, "", nil); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			createdRequest = append(createdRequest, modelRequest)
+		}
+		if len(failedOccurrences) > 0 {
+			return response.ErrorBuilderWithData(
+				http.StatusBadRequest,
+				errors.New("recurrence_conflict"),
+				fmt.Sprintf("%d dari %d jadwal berulang tidak tersedia", len(failedOccurrences), len(occurrenceStarts)),
+				map[string]interface{}{
+					"occurrences": failedOccurrences,
+				},
+			)
 		}
 
+		// file diupload sekali ke drive lalu dicatat di setiap kejadian
 		for _, file := range payload.Files {
 			f, err := file.Open()
 			if err != nil {
@@ -182,31 +286,65 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestCreateReq
 			}
 			allFileUploaded = append(allFileUploaded, newFile.Id)
 
-			modelFile := &model.FileEntityModel{
-				Context: ctx,
-				FileEntity: model.FileEntity{
-					RequestId: modelRequest.ID,
-					File:      newFile.Id,
-					FileName:  newFile.Name,
-					IsDelete:  false,
-				},
+			for _, v := range createdRequest {
+				modelFile := &model.FileEntityModel{
+					Context: ctx,
+					FileEntity: model.FileEntity{
+						RequestId: v.ID,
+						File:      newFile.Id,
+						FileName:  newFile.Name,
+						IsDelete:  false,
+					},
+				}
+				if err := s.FileRepository.Create(ctx, modelFile).Error; err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
 			}
-			if err := s.FileRepository.Create(ctx, modelFile).Error; err != nil {
+		}
+
+		// file hasil clone disalin di drive agar tidak bergantung pada berkas request asal
+		for _, file := range payload.CopyFiles {
+			newFile, err := gdrive.CopyFile(s.sDrive, file.File, file.FileName, s.fDrive.Id)
+			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
+			allFileUploaded = append(allFileUploaded, newFile.Id)
+
+			for _, v := range createdRequest {
+				modelFile := &model.FileEntityModel{
+					Context: ctx,
+					FileEntity: model.FileEntity{
+						RequestId: v.ID,
+						File:      newFile.Id,
+						FileName:  newFile.Name,
+						IsDelete:  false,
+					},
+				}
+				if err := s.FileRepository.Create(ctx, modelFile).Error; err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+			}
 		}
 
+		notifMessage := payload.EventName
+		if seriesId != nil {
+			notifMessage = fmt.Sprintf("%s (%d jadwal berulang)", payload.EventName, len(createdRequest))
+		}
 		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
 		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 		}
+		if assignee != nil {
+			userBM = []*model.UserEntityModel{assignee}
+		}
 		for _, v := range userBM {
-			err := SendNotif(s, ctx, "Event Baru!", modelRequest.EventName, v.ID, modelRequest.ID)
+			recipient, err := SendNotif(s, ctx, "Event Baru!", notifMessage, v.ID, createdRequest[0].ID)
 			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
-			sendNotifTo = append(sendNotifTo, v.ID)
+			sendNotifTo = append(sendNotifTo, recipient)
 		}
+		createdId = createdRequest[0].ID
 
 		return nil
 	}); err != nil {
@@ -227,24 +365,88 @@ func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestCreateReq
 
 	return map[string]interface{}{
 		"message": "success create!",
+		"id":      createdId,
 	}, nil
 }
 
 func (s *service) Find(ctx *abstraction.Context, payload *dto.RequestFindRequest) (map[string]interface{}, error) {
 	var (
-		res  []map[string]interface{} = nil
-		alts []general.AltRaw
+		res            []map[string]interface{} = nil
+		data           []*model.RequestEntityModel
+		count          *int
+		rankMap                               = map[int]ahpRankItem{}
+		ahpCriteriaRes map[string]interface{} = nil
+		err            error
 	)
-	data, err := s.RequestRepository.Find(ctx, false)
-	if err != nil && err.Error() != "record not found" {
-		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
-	}
-	count, err := s.RequestRepository.Count(ctx)
-	if err != nil && err.Error() != "record not found" {
-		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+
+	// ahp: ranking dihitung atas request open yang lolos filter (lihat rankRequests), lalu dipaginasi berdasarkan urutan ranking
+	if payload.UseAhp != nil && *payload.UseAhp == "yes" {
+		fmt.Println("=== [AHP MODE AKTIF] ===")
+		method := constant.AHP_METHOD_APPROXIMATE
+		if payload.AhpMethod != nil && *payload.AhpMethod != "" {
+			if !general.IsValidAhpMethod(*payload.AhpMethod) {
+				return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "ahp_method must be approximate or eigenvector")
+			}
+			method = *payload.AhpMethod
+		}
+		general.AddUsePriorityCount(s.DbRedis)
+
+		criteriaMatrix, profileRes, err := ahpCriteriaMatrix(s, ctx, payload.AhpProfileId)
+		if err != nil {
+			return nil, err
+		}
+		ranking, err := rankRequests(s, ctx, criteriaMatrix, payload.EventComplexity, method)
+		if err != nil {
+			return nil, err
+		}
+		criteriaWeightRes := map[string]interface{}{}
+		for i, name := range constant.AHP_CRITERIA {
+			if i < len(ranking.CriteriaWeights) {
+				criteriaWeightRes[name] = ranking.CriteriaWeights[i]
+			}
+		}
+		ahpCriteriaRes = profileRes
+		ahpCriteriaRes["method"] = ranking.Method
+		ahpCriteriaRes["weights"] = criteriaWeightRes
+		ahpCriteriaRes["lambda_max"] = ranking.CriteriaLambdaMax
+		ahpCriteriaRes["consistency_ratio"] = ranking.CriteriaCR
+
+		total := len(ranking.Items)
+		count = &total
+		limit, offset := general.ProcessLimitOffset(ctx, false)
+		var pageIds []int
+		for i := offset; i < total && i-offset < limit; i++ {
+			pageIds = append(pageIds, ranking.Items[i].ID)
+			rankMap[ranking.Items[i].ID] = ranking.Items[i]
+		}
+		if len(pageIds) > 0 {
+			data, err = s.RequestRepository.FindByIds(ctx, pageIds)
+			if err != nil && err.Error() != "record not found" {
+				return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			sort.SliceStable(data, func(i, j int) bool {
+				return rankMap[data[i].ID].Rank < rankMap[data[j].ID].Rank
+			})
+		}
+	} else {
+		data, err = s.RequestRepository.Find(ctx, false)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		count, err = s.RequestRepository.Count(ctx)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
 	}
 
 	for _, v := range data {
+		var assigneeRes map[string]interface{} = nil
+		if v.Assignee != nil {
+			assigneeRes = map[string]interface{}{
+				"id":   v.Assignee.ID,
+				"name": v.Assignee.Name,
+			}
+		}
 		resData := map[string]interface{}{
 			"id": v.ID,
 			"user": map[string]interface{}{
@@ -253,6 +455,7 @@ func (s *service) Find(ctx *abstraction.Context, payload *dto.RequestFindRequest
 			},
 			"event_name":       v.EventName,
 			"event_location":   v.EventLocation,
+			"location_id":      v.LocationId,
 			"event_date_start": general.FormatWithZWithoutChangingTime(v.EventDateStart),
 			"event_date_end":   general.FormatWithZWithoutChangingTime(v.EventDateEnd),
 			"event_type": map[string]interface{}{
@@ -264,215 +467,31 @@ func (s *service) Find(ctx *abstraction.Context, payload *dto.RequestFindRequest
 				"id":   v.Status.ID,
 				"name": v.Status.Name,
 			},
-			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
-		}
-		res = append(res, resData)
-
-		alts = append(alts, general.AltRaw{
-			ID:                v.ID,
-			UserID:            v.User.ID,
-			UserName:          v.User.Name,
-			EventName:         v.EventName,
-			EventLocation:     v.EventLocation,
-			EventDateStart:    v.EventDateStart,
-			EventDateEnd:      v.EventDateEnd,
-			Description:       v.Description,
-			EventTypeID:       v.EventType.ID,
-			EventTypeName:     v.EventType.Name,
-			EventTypePriority: v.EventType.Priority,
-			StatusID:          v.Status.ID,
-			StatusName:        v.Status.Name,
-			CountParticipant:  v.CountParticipant,
-			CreatedAt:         v.CreatedAt,
-			UpdatedAt:         v.UpdatedAt,
-		})
-	}
-
-	// ahp
-	if payload.UseAhp != nil && *payload.UseAhp == "yes" {
-		fmt.Println("=== [AHP MODE AKTIF] ===")
-		general.AddUsePriorityCount(s.DbRedis)
-
-		complexityMap := map[int]float64{}
-		if payload.EventComplexity != nil && *payload.EventComplexity != "" {
-			fmt.Println("-> Parsing EventComplexity JSON...")
-			complexityMap = general.ParseComplexities(*payload.EventComplexity)
-			fmt.Println("   Hasil parsing complexityMap:", complexityMap)
+			"status_reason": v.StatusReason,
+			"series_id":     v.SeriesId,
+			"series_index":  v.SeriesIndex,
+			"assignee":      assigneeRes,
+			"version":       v.Version,
+			"is_overdue":    v.IsOverdue,
+			"created_at":    general.FormatWithZWithoutChangingTime(v.CreatedAt),
 		}
-
-		n := len(alts)
-		if n > 0 {
-			fmt.Printf("-> Jumlah alternatif: %d\n", n)
-
-			// siapkan slice skor
-			urgencyScores := make([]float64, n)
-			importanceScores := make([]float64, n)
-			participantScores := make([]float64, n)
-			complexityScores := make([]float64, n)
-
-			for i, a := range alts {
-				urgencyScores[i] = general.ComputeUrgencyScore(a.CreatedAt, a.EventDateStart)
-				priority := a.EventTypePriority
-				if priority <= 0 {
-					priority = 1
-				}
-				importanceScores[i] = float64(1) / float64(priority)
-				participantScores[i] = float64(a.CountParticipant)
-
-				compVal := 1.0
-				if c, ok := complexityMap[a.ID]; ok {
-					compVal = c
-				}
-				complexityScores[i] = 6.0 - compVal
-			}
-
-			fmt.Println("\n--- [SKOR AWAL SETIAP KRITERIA] ---")
-			for i, a := range alts {
-				fmt.Printf("%d. %s\n", i+1, a.EventName)
-				fmt.Printf("   Urgency: %.4f\n", urgencyScores[i])
-				fmt.Printf("   Importance: %.4f\n", importanceScores[i])
-				fmt.Printf("   Participants: %.4f\n", participantScores[i])
-				fmt.Printf("   Complexity: %.4f\n", complexityScores[i])
-			}
-
-			critNames := []string{"Urgency", "Importance", "Participants", "Complexity"}
-			critImportanceRaw := []float64{5, 3, 2, 1}
-			fmt.Println("\n--- [KRITERIA UTAMA] ---")
-			fmt.Println("Nama:", critNames)
-			fmt.Println("Bobot Awal:", critImportanceRaw)
-
-			criteriaMatrix := general.BuildPairwiseFromScores(critImportanceRaw)
-			fmt.Println("\nMatriks Perbandingan Kriteria:")
-			general.PrintMatrix(criteriaMatrix)
-
-			criteriaWeights, criteriaCR := general.CalculateAHP(criteriaMatrix)
-			fmt.Printf("Bobot Kriteria: %.4f %.4f %.4f %.4f\n", criteriaWeights[0], criteriaWeights[1], criteriaWeights[2], criteriaWeights[3])
-			fmt.Printf("CR (Consistency Ratio): %.4f\n", criteriaCR)
-
-			// build pairwise alternative matrices
-			mUrgency := general.BuildPairwiseFromScores(urgencyScores)
-			wUrgency, crUrgency := general.CalculateAHP(mUrgency)
-			fmt.Println("\n--- [AHP Urgency] ---")
-			general.PrintMatrix(mUrgency)
-			fmt.Println("Bobot alternatif:", wUrgency)
-			fmt.Printf("CR: %.4f\n", crUrgency)
-
-			mImportance := general.BuildPairwiseFromScores(importanceScores)
-			wImportance, crImportance := general.CalculateAHP(mImportance)
-			fmt.Println("\n--- [AHP Importance] ---")
-			general.PrintMatrix(mImportance)
-			fmt.Println("Bobot alternatif:", wImportance)
-			fmt.Printf("CR: %.4f\n", crImportance)
-
-			mParticipant := general.BuildPairwiseFromScores(participantScores)
-			wParticipant, crParticipant := general.CalculateAHP(mParticipant)
-			fmt.Println("\n--- [AHP Participants] ---")
-			general.PrintMatrix(mParticipant)
-			fmt.Println("Bobot alternatif:", wParticipant)
-			fmt.Printf("CR: %.4f\n", crParticipant)
-
-			mComplexity := general.BuildPairwiseFromScores(complexityScores)
-			wComplexity, crComplexity := general.CalculateAHP(mComplexity)
-			fmt.Println("\n--- [AHP Complexity] ---")
-			general.PrintMatrix(mComplexity)
-			fmt.Println("Bobot alternatif:", wComplexity)
-			fmt.Printf("CR: %.4f\n", crComplexity)
-
-			// hitung skor akhir
-			finalScores := make([]float64, n)
-			for i := 0; i < n; i++ {
-				finalScores[i] = criteriaWeights[0]*wUrgency[i] +
-					criteriaWeights[1]*wImportance[i] +
-					criteriaWeights[2]*wParticipant[i] +
-					criteriaWeights[3]*wComplexity[i]
-			}
-
-			fmt.Println("\n--- [FINAL SCORE SETIAP ALTERNATIF] ---")
-			for i, a := range alts {
-				fmt.Printf("%s: %.6f\n", a.EventName, finalScores[i])
-			}
-
-			// ranking
-			type rItem struct {
-				ID    int
-				Name  string
-				Score float64
-			}
-			var ranked []rItem
-			for i, a := range alts {
-				ranked = append(ranked, rItem{
-					ID:    a.ID,
-					Name:  a.EventName,
-					Score: finalScores[i],
-				})
-			}
-			sort.SliceStable(ranked, func(i, j int) bool {
-				return ranked[i].Score > ranked[j].Score
-			})
-
-			fmt.Println("\n--- [RANKING AKHIR] ---")
-			for i, r := range ranked {
-				fmt.Printf("%d. %s (Score: %.6f)\n", i+1, r.Name, r.Score)
-			}
-
-			// siapkan hasil ahpResult
-			altResults := []map[string]interface{}{}
-			for idx, it := range ranked {
-				altResults = append(altResults, map[string]interface{}{
-					"rank":  idx + 1,
-					"id":    it.ID,
-					"name":  it.Name,
-					"score": it.Score,
-				})
-			}
-
-			if len(res) == len(alts) {
-				// buat map untuk lookup skor AHP berdasarkan ID
-				scoreMap := make(map[int]float64)
-				for _, r := range ranked {
-					scoreMap[r.ID] = r.Score
-				}
-
-				// normalisasi skor jadi persen (0–100)
-				maxScore := ranked[0].Score
-				minScore := ranked[len(ranked)-1].Score
-				diff := maxScore - minScore
-				if diff == 0 {
-					diff = 1 // biar gak bagi 0
-				}
-
-				// tambahkan ahp_score ke setiap item res sesuai ID
-				for _, r := range res {
-					id, ok := r["id"].(int)
-					if !ok {
-						continue
-					}
-					score := scoreMap[id]
-					total := 0.0
-					for _, v := range scoreMap {
-						total += v
-					}
-					percent := (score / total) * 100
-					r["ahp_score"] = map[string]interface{}{
-						"raw":     score,
-						"percent": fmt.Sprintf("%.2f%%", percent),
-					}
-				}
-
-				// urutkan ulang res berdasarkan ranking
-				sort.SliceStable(res, func(i, j int) bool {
-					idI, _ := res[i]["id"].(int)
-					idJ, _ := res[j]["id"].(int)
-					return scoreMap[idI] > scoreMap[idJ]
-				})
+		if item, ok := rankMap[v.ID]; ok {
+			resData["ahp_score"] = map[string]interface{}{
+				"rank":    item.Rank,
+				"raw":     item.Score,
+				"percent": fmt.Sprintf("%.2f%%", item.Percent),
 			}
 		}
+		res = append(res, resData)
 	}
 
 	resp := map[string]interface{}{
 		"count": count,
 		"data":  res,
 	}
+	if ahpCriteriaRes != nil {
+		resp["ahp_criteria"] = ahpCriteriaRes
+	}
 
 	return resp, nil
 }
@@ -484,39 +503,114 @@ func (s *service) FindById(ctx *abstraction.Context, payload *dto.RequestFindByI
 		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 	}
 	if data != nil {
-		res = map[string]interface{}{
-			"id": data.ID,
-			"user": map[string]interface{}{
-				"id":   data.User.ID,
-				"name": data.User.Name,
-			},
-			"event_name":       data.EventName,
-			"event_location":   data.EventLocation,
-			"event_date_start": general.FormatWithZWithoutChangingTime(data.EventDateStart),
-			"event_date_end":   general.FormatWithZWithoutChangingTime(data.EventDateEnd),
-			"description":      data.Description,
-			"event_type": map[string]interface{}{
-				"id":       data.EventType.ID,
-				"name":     data.EventType.Name,
-				"priority": data.EventType.Priority,
-			},
-			"count_participant": data.CountParticipant,
-			"status": map[string]interface{}{
-				"id":   data.Status.ID,
-				"name": data.Status.Name,
-			},
-			"created_at": general.FormatWithZWithoutChangingTime(data.CreatedAt),
-			"updated_at": general.FormatWithZWithoutChangingTime(*data.UpdatedAt),
+		res = requestDetail(data)
+
+		assets, err := s.RequestAssetRepository.FindByRequestId(ctx, data.ID)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		var assetRes []map[string]interface{} = nil
+		for _, v := range assets {
+			assetRes = append(assetRes, map[string]interface{}{
+				"asset_id":  v.AssetId,
+				"name":      v.Asset.Name,
+				"item_type": v.Asset.ItemType,
+				"quantity":  v.Quantity,
+			})
 		}
+		res["assets"] = assetRes
+
+		budgetTotals, err := s.RequestBudgetRepository.SumByRequestIds(ctx, []int{data.ID})
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		budgetRes := map[string]interface{}{
+			"total_estimated": int64(0),
+			"total_actual":    int64(0),
+		}
+		for _, v := range budgetTotals {
+			budgetRes["total_estimated"] = v.Estimated
+			budgetRes["total_actual"] = v.Actual
+		}
+		res["budget"] = budgetRes
+
+		evaluationSummary, err := s.RequestEvaluationRepository.Summarize(ctx, "id", &data.ID)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		var evaluationData *model.RequestEvaluationSummary = nil
+		if len(evaluationSummary) > 0 {
+			evaluationData = evaluationSummary[0]
+		}
+		res["evaluation"] = evaluation.SummaryResponse(evaluationData)
 	}
 	return map[string]interface{}{
 		"data": res,
 	}, nil
 }
 
+// requestDetail: bentuk response detail request, dipakai FindById dan data terbaru saat version bentrok
+func requestDetail(data *model.RequestEntityModel) map[string]interface{} {
+	var escalatedAt interface{} = nil
+	if data.EscalatedAt != nil {
+		escalatedAt = general.FormatWithZWithoutChangingTime(*data.EscalatedAt)
+	}
+	var assigneeRes map[string]interface{} = nil
+	if data.Assignee != nil {
+		assigneeRes = map[string]interface{}{
+			"id":   data.Assignee.ID,
+			"name": data.Assignee.Name,
+		}
+	}
+	var locationRes map[string]interface{} = nil
+	if data.Location != nil {
+		locationRes = map[string]interface{}{
+			"id":         data.Location.ID,
+			"name":       data.Location.Name,
+			"building":   data.Location.Building,
+			"floor":      data.Location.Floor,
+			"capacity":   data.Location.Capacity,
+			"facilities": data.Location.Facilities,
+		}
+	}
+	return map[string]interface{}{
+		"id": data.ID,
+		"user": map[string]interface{}{
+			"id":   data.User.ID,
+			"name": data.User.Name,
+		},
+		"event_name":       data.EventName,
+		"event_location":   data.EventLocation,
+		"location":         locationRes,
+		"event_date_start": general.FormatWithZWithoutChangingTime(data.EventDateStart),
+		"event_date_end":   general.FormatWithZWithoutChangingTime(data.EventDateEnd),
+		"description":      data.Description,
+		"event_type": map[string]interface{}{
+			"id":       data.EventType.ID,
+			"name":     data.EventType.Name,
+			"priority": data.EventType.Priority,
+		},
+		"count_participant": data.CountParticipant,
+		"status": map[string]interface{}{
+			"id":   data.Status.ID,
+			"name": data.Status.Name,
+		},
+		"status_reason": data.StatusReason,
+		"series_id":     data.SeriesId,
+		"series_index":  data.SeriesIndex,
+		"assignee":      assigneeRes,
+		"version":       data.Version,
+		"is_overdue":    data.IsOverdue,
+		"escalated_at":  escalatedAt,
+		"created_at":    general.FormatWithZWithoutChangingTime(data.CreatedAt),
+		"updated_at":    general.FormatWithZWithoutChangingTime(*data.UpdatedAt),
+	}
+}
+
 func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateRequest) (map[string]interface{}, error) {
 	var (
 		sendNotifTo      []int
+		newVersion       int
 		statusesForAdmin = []int{
 			constant.STATUS_ID_PROSES,
 			constant.STATUS_ID_FINALISASI,
@@ -532,6 +626,29 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
 		}
 
+		if requestData.StatusId == constant.STATUS_ID_DRAFT && requestData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		version, err := general.ProcessVersion(ctx, payload.Version)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), err.Error())
+		}
+		if version != nil && *version != requestData.Version {
+			return response.ErrorVersionConflict(requestDetail(requestData))
+		}
+
+		applyFollowing := payload.Scope != nil && *payload.Scope == "following"
+		var pendingChecks []occurrenceCheck
+		if applyFollowing {
+			if requestData.SeriesId == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not part of a series")
+			}
+			if payload.StatusId != nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "status of a series must be changed through series status")
+			}
+		}
+
 		reloadData := false
 		newRequestData := new(model.RequestEntityModel)
 		newRequestData.Context = ctx
@@ -540,9 +657,6 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 			newRequestData.EventName = *payload.EventName
 			reloadData = true
 		}
-		if payload.EventLocation != nil {
-			newRequestData.EventLocation = *payload.EventLocation
-		}
 		if payload.EventDateStart != nil {
 			parsedEventDateStart, err := general.Parse("2006-01-02 15:04:05", *payload.EventDateStart)
 			if err != nil {
@@ -560,8 +674,9 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 		if payload.Description != nil {
 			newRequestData.Description = *payload.Description
 		}
+		eventTypeData := &requestData.EventType
 		if payload.EventTypeId != nil {
-			eventTypeData, err := s.EventTypeRepository.FindById(ctx, *payload.EventTypeId)
+			eventTypeData, err = s.EventTypeRepository.FindById(ctx, *payload.EventTypeId)
 			if err != nil && err.Error() != "record not found" {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
@@ -573,7 +688,73 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 		if payload.CountParticipant != nil {
 			newRequestData.CountParticipant = *payload.CountParticipant
 		}
-		if payload.StatusId != nil {
+		checkLocationId := requestData.LocationId
+		if payload.LocationId != nil {
+			checkLocationId = payload.LocationId
+		}
+		var locationData *model.LocationEntityModel
+		// request lama yang belum dimigrasi belum punya location_id, kapasitas dan bentrok belum bisa dicek
+		if checkLocationId != nil && (payload.LocationId != nil || payload.CountParticipant != nil) {
+			checkCountParticipant := requestData.CountParticipant
+			if payload.CountParticipant != nil {
+				checkCountParticipant = *payload.CountParticipant
+			}
+			locationData, err = findBookableLocation(s, ctx, *checkLocationId, checkCountParticipant)
+			if err != nil {
+				return err
+			}
+			if payload.LocationId != nil {
+				newRequestData.LocationId = &locationData.ID
+				newRequestData.EventLocation = locationData.Name
+			}
+		}
+		if payload.LocationId != nil || payload.EventDateStart != nil || payload.EventDateEnd != nil || payload.EventTypeId != nil {
+			checkStart := requestData.EventDateStart
+			if payload.EventDateStart != nil {
+				checkStart = newRequestData.EventDateStart
+			}
+			checkEnd := requestData.EventDateEnd
+			if payload.EventDateEnd != nil {
+				checkEnd = newRequestData.EventDateEnd
+			}
+			if !checkStart.Before(checkEnd) {
+				return response.ErrorBuilder(
+					http.StatusBadRequest,
+					errors.New("bad_request"),
+					"Tanggal mulai harus lebih kecil dari tanggal selesai",
+				)
+			}
+			if checkLocationId != nil && !slices.Contains(nonBookingStatuses, requestData.StatusId) {
+				if locationData == nil {
+					locationData, err = s.LocationRepository.FindById(ctx, *checkLocationId)
+					if err != nil && err.Error() != "record not found" {
+						return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+					}
+					if locationData == nil {
+						return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+					}
+				}
+				if applyFollowing {
+					// dicek setelah kejadian berikutnya ikut digeser, lihat updateFollowingOccurrences
+					pendingChecks = append(pendingChecks, occurrenceCheck{
+						id:        requestData.ID,
+						location:  locationData,
+						start:     checkStart,
+						end:       checkEnd,
+						eventType: eventTypeData,
+					})
+				} else if err = checkBookingConflict(s, ctx, requestData.ID, locationData, checkStart, checkEnd, eventTypeData); err != nil {
+					return err
+				}
+			}
+			if !slices.Contains(nonBookingStatuses, requestData.StatusId) {
+				if err = checkAssetShortage(s, ctx, requestData.ID, checkStart, checkEnd, eventTypeData); err != nil {
+					return err
+				}
+			}
+		}
+		var onBehalfOf *int
+		if payload.StatusId != nil && *payload.StatusId != requestData.StatusId {
 			statusData, err := s.StatusRepository.FindById(ctx, *payload.StatusId)
 			if err != nil && err.Error() != "record not found" {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
@@ -581,11 +762,51 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 			if statusData == nil {
 				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "status not found")
 			}
+			note := ""
+			if payload.Note != nil {
+				note = strings.TrimSpace(*payload.Note)
+			}
+			onBehalfOf, err = validateStatusTransition(s, ctx, requestData, *payload.StatusId, note)
+			if err != nil {
+				return err
+			}
 			newRequestData.StatusId = *payload.StatusId
+			if isBookingReleased(*payload.StatusId) {
+				newRequestData.StatusReason = note
+			}
 			reloadData = true
 		}
-		if err = s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
-			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+		newVersion = newRequestData.Version
+
+		if applyFollowing {
+			var shiftStart, shiftEnd time.Duration
+			if payload.EventDateStart != nil {
+				shiftStart = newRequestData.EventDateStart.Sub(requestData.EventDateStart)
+			}
+			if payload.EventDateEnd != nil {
+				shiftEnd = newRequestData.EventDateEnd.Sub(requestData.EventDateEnd)
+			}
+			if err = updateFollowingOccurrences(s, ctx, requestData, newRequestData, eventTypeData, shiftStart, shiftEnd, pendingChecks); err != nil {
+				return err
+			}
+		}
+
+		var approverIds []int
+		if newRequestData.StatusId != 0 {
+			note := ""
+			if payload.Note != nil {
+				note = *payload.Note
+			}
+			if err = createStatusHistory(s, ctx, requestData.ID, &requestData.StatusId, newRequestData.StatusId, note, onBehalfOf); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
 		}
 
 		if reloadData {
@@ -595,6 +816,19 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 			}
 		}
 
+		// status diubah di atas sehingga requestData sudah dimuat ulang
+		if newRequestData.StatusId == constant.STATUS_ID_VALIDASI {
+			approverIds, err = startApprovalChain(s, ctx, requestData)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		// draft belum diajukan ke BM, jadi tidak ada notifikasi
+		if requestData.StatusId == constant.STATUS_ID_DRAFT {
+			return nil
+		}
+
 		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
 		if err != nil && err.Error() != "record not found" {
 			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
@@ -610,7 +844,7 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 		}
 		switch ctx.Auth.RoleID {
 		case constant.ROLE_ID_STAF:
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 			if slices.Contains(statusesForAdmin, requestData.StatusId) {
 				addIDs(userAdmin)
 			}
@@ -621,15 +855,16 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 			}
 		case constant.ROLE_ID_ADMIN:
 			sendNotifTo = append(sendNotifTo, requestData.UserId)
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 		}
 
-		for _, v := range sendNotifTo {
-			err = SendNotif(s, ctx, "Event diperbarui!", requestData.EventName, v, requestData.ID)
+		for i, v := range sendNotifTo {
+			sendNotifTo[i], err = SendNotif(s, ctx, "Event diperbarui!", requestData.EventName, v, requestData.ID)
 			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
 		}
+		sendNotifTo = append(sendNotifTo, approverIds...)
 
 		return nil
 	}); err != nil {
@@ -644,6 +879,7 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestUpdateReq
 
 	return map[string]interface{}{
 		"message": "success update!",
+		"version": newVersion,
 	}, nil
 }
 
@@ -673,8 +909,18 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.RequestDeleteByI
 		newRequestData.Context = ctx
 		newRequestData.ID = requestData.ID
 		newRequestData.IsDelete = true
-		if err = s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
-			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		newRequestData.DeletedAt = general.NowWithLocation()
+		result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+
+		// draft belum diajukan ke BM, jadi tidak ada notifikasi
+		if requestData.StatusId == constant.STATUS_ID_DRAFT {
+			return nil
 		}
 
 		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
@@ -692,7 +938,7 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.RequestDeleteByI
 		}
 		switch ctx.Auth.RoleID {
 		case constant.ROLE_ID_STAF:
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 			if slices.Contains(statusesForAdmin, requestData.StatusId) {
 				addIDs(userAdmin)
 			}
@@ -703,11 +949,11 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.RequestDeleteByI
 			}
 		case constant.ROLE_ID_ADMIN:
 			sendNotifTo = append(sendNotifTo, requestData.UserId)
-			addIDs(userBM)
+			addIDs(requestData.HandlerUsers(userBM))
 		}
 
-		for _, v := range sendNotifTo {
-			err = SendNotif(s, ctx, "Event dihapus!", requestData.EventName, v, requestData.ID)
+		for i, v := range sendNotifTo {
+			sendNotifTo[i], err = SendNotif(s, ctx, "Event dihapus!", requestData.EventName, v, requestData.ID)
 			if err != nil {
 				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 			}
@@ -758,6 +1004,25 @@ func (s *service) Export(ctx *abstraction.Context, payload *dto.RequestExportReq
 
 	data = filteredData
 
+	var requestIds []int
+	for _, v := range data {
+		requestIds = append(requestIds, v.ID)
+	}
+	budgetTotals, err := s.RequestBudgetRepository.SumByRequestIds(ctx, requestIds)
+	if err != nil && err.Error() != "record not found" {
+		return "", nil, "", response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	budgetMap := make(map[int]*model.RequestBudgetTotal)
+	for _, v := range budgetTotals {
+		budgetMap[v.RequestID] = v
+	}
+	budgetOf := func(requestId int) (int64, int64) {
+		if v, ok := budgetMap[requestId]; ok {
+			return v.Estimated, v.Actual
+		}
+		return 0, 0
+	}
+
 	if payload.Format == "pdf" {
 		pdf := gofpdf.New("P", "mm", "A4", "")
 		pdf.SetMargins(15, 10, 15)
@@ -782,6 +1047,12 @@ func (s *service) Export(ctx *abstraction.Context, payload *dto.RequestExportReq
 			pdf.MultiCell(0, 6, fmt.Sprintf("Tanggal Selesai   : %s", general.ConvertDateTimeToIndonesian(v.EventDateEnd.Format("2006-01-02 15:04:05"))), "", "", false)
 			pdf.MultiCell(0, 6, fmt.Sprintf("Tanggal Pengajuan : %s", general.ConvertDateTimeToIndonesian(v.CreatedAt.Format("2006-01-02 15:04:05"))), "", "", false)
 			pdf.MultiCell(0, 6, fmt.Sprintf("Status            : %s", v.Status.Name), "", "", false)
+			if v.StatusReason != "" {
+				pdf.MultiCell(0, 6, fmt.Sprintf("Alasan            : %s", v.StatusReason), "", "", false)
+			}
+			estimated, actual := budgetOf(v.ID)
+			pdf.MultiCell(0, 6, fmt.Sprintf("Estimasi Biaya    : %s", general.FormatRupiah(estimated)), "", "", false)
+			pdf.MultiCell(0, 6, fmt.Sprintf("Realisasi Biaya   : %s", general.FormatRupiah(actual)), "", "", false)
 
 			pdf.Ln(6)
 			pdf.SetDrawColor(200, 200, 200)
@@ -819,6 +1090,9 @@ func (s *service) Export(ctx *abstraction.Context, payload *dto.RequestExportReq
 		f.SetCellValue(sheet, "G1", "Tipe Event")
 		f.SetCellValue(sheet, "H1", "Tanggal Pengajuan")
 		f.SetCellValue(sheet, "I1", "Status")
+		f.SetCellValue(sheet, "J1", "Alasan Status")
+		f.SetCellValue(sheet, "K1", "Estimasi Biaya")
+		f.SetCellValue(sheet, "L1", "Realisasi Biaya")
 
 		for i, v := range data {
 			colA := fmt.Sprintf("A%d", i+2)
@@ -830,6 +1104,9 @@ func (s *service) Export(ctx *abstraction.Context, payload *dto.RequestExportReq
 			colG := fmt.Sprintf("G%d", i+2)
 			colH := fmt.Sprintf("H%d", i+2)
 			colI := fmt.Sprintf("I%d", i+2)
+			colJ := fmt.Sprintf("J%d", i+2)
+			colK := fmt.Sprintf("K%d", i+2)
+			colL := fmt.Sprintf("L%d", i+2)
 			no := i + 1
 			f.SetCellValue(sheet, colA, no)
 			f.SetCellValue(sheet, colB, v.User.Name)
@@ -840,6 +1117,10 @@ func (s *service) Export(ctx *abstraction.Context, payload *dto.RequestExportReq
 			f.SetCellValue(sheet, colG, v.EventType.Name)
 			f.SetCellValue(sheet, colH, general.ConvertDateTimeToIndonesian(v.CreatedAt.Format("2006-01-02 15:04:05")))
 			f.SetCellValue(sheet, colI, v.Status.Name)
+			f.SetCellValue(sheet, colJ, v.StatusReason)
+			estimated, actual := budgetOf(v.ID)
+			f.SetCellValue(sheet, colK, estimated)
+			f.SetCellValue(sheet, colL, actual)
 		}
 
 		styleID, _ := f.NewStyle(&excelize.Style{
@@ -850,7 +1131,7 @@ func (s *service) Export(ctx *abstraction.Context, payload *dto.RequestExportReq
 		})
 		f.SetCellStyle(sheet, "A1", fmt.Sprintf("M%d", len(data)+1), styleID)
 
-		cols := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I"}
+		cols := []string{"A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L"}
 		lastRow := len(data) + 1
 
 		for _, col := range cols {
@@ -981,8 +1262,9 @@ func (s *service) ExportById(ctx *abstraction.Context, payload *dto.RequestExpor
 	pdf.Ln(5)
 
 	var (
-		linkFiles       []map[string]interface{}
-		historyComments []string
+		linkFiles          []map[string]interface{}
+		historyComments    []string
+		evaluationComments []string
 	)
 
 	fileData, err := s.FileRepository.FindByRequestId(ctx, data.ID, true)
@@ -1013,10 +1295,29 @@ func (s *service) ExportById(ctx *abstraction.Context, payload *dto.RequestExpor
 			comment.Comment))
 	}
 
-	pdf.SetDrawColor(200, 200, 200)
-	pdf.SetFillColor(248, 248, 248)
-	pdf.SetLineWidth(0.4)
-	pdf.Rect(15, pdf.GetY(), 180, 0, "D")
+	evaluationData, err := s.RequestEvaluationRepository.FindByRequestId(ctx, data.ID)
+	if err != nil && err.Error() != "record not found" {
+		return "", nil, "", response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	evaluationSummary, err := s.RequestEvaluationRepository.Summarize(ctx, "id", &data.ID)
+	if err != nil && err.Error() != "record not found" {
+		return "", nil, "", response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range evaluationData {
+		if strings.TrimSpace(v.Comment) == "" {
+			continue
+		}
+		evaluationComments = append(evaluationComments, fmt.Sprintf("[%d/5] %s: %s",
+			v.RatingOverall,
+			v.User.Name,
+			v.Comment))
+	}
+
+	pdf.SetDrawColor(200, 200, 200)
+	pdf.SetFillColor(248, 248, 248)
+	pdf.SetLineWidth(0.4)
+	pdf.Rect(15, pdf.GetY(), 180, 0, "D")
 	pdf.Ln(2)
 
 	pdf.SetFont("Arial", "B", 11)
@@ -1059,6 +1360,13 @@ func (s *service) ExportById(ctx *abstraction.Context, payload *dto.RequestExpor
 	pdf.SetFont("Arial", "", 11)
 	pdf.MultiCell(0, 7, fmt.Sprintf(": %s", data.Status.Name), "", "L", false)
 
+	if data.StatusReason != "" {
+		pdf.SetFont("Arial", "B", 11)
+		pdf.CellFormat(40, 7, "Alasan", "0", 0, "", false, 0, "")
+		pdf.SetFont("Arial", "", 11)
+		pdf.MultiCell(0, 7, fmt.Sprintf(": %s", data.StatusReason), "", "L", false)
+	}
+
 	pdf.Ln(4)
 	pdf.SetDrawColor(220, 220, 220)
 	pdf.Line(15, pdf.GetY(), 195, pdf.GetY())
@@ -1088,6 +1396,43 @@ func (s *service) ExportById(ctx *abstraction.Context, payload *dto.RequestExpor
 		pdf.Ln(2)
 	}
 
+	if len(evaluationSummary) > 0 {
+		summary := evaluation.SummaryResponse(evaluationSummary[0])
+		pdf.SetFont("Arial", "B", 12)
+		pdf.CellFormat(0, 7, "Evaluasi", "", 1, "", false, 0, "")
+
+		evaluationRows := [][2]string{
+			{"Jumlah Evaluasi", fmt.Sprintf("%d", summary["count"])},
+			{"Rating Lokasi", fmt.Sprintf("%.2f / 5", summary["rating_location"])},
+			{"Rating Fasilitas", fmt.Sprintf("%.2f / 5", summary["rating_facility"])},
+			{"Rating Layanan", fmt.Sprintf("%.2f / 5", summary["rating_service"])},
+			{"Rating Keseluruhan", fmt.Sprintf("%.2f / 5", summary["rating_overall"])},
+		}
+		for _, row := range evaluationRows {
+			pdf.SetFont("Arial", "B", 11)
+			pdf.CellFormat(40, 7, row[0], "0", 0, "", false, 0, "")
+			pdf.SetFont("Arial", "", 11)
+			pdf.MultiCell(0, 7, fmt.Sprintf(": %s", row[1]), "", "L", false)
+		}
+		for _, v := range evaluationData {
+			if v.ActualAttendance == nil {
+				continue
+			}
+			pdf.SetFont("Arial", "B", 11)
+			pdf.CellFormat(40, 7, "Kehadiran Aktual", "0", 0, "", false, 0, "")
+			pdf.SetFont("Arial", "", 11)
+			pdf.MultiCell(0, 7, fmt.Sprintf(": %d dari %d orang", *v.ActualAttendance, data.CountParticipant), "", "L", false)
+			break
+		}
+
+		pdf.SetFont("Arial", "", 11)
+		for _, c := range evaluationComments {
+			pdf.MultiCell(0, 7, c, "", "L", false)
+			pdf.Ln(2)
+		}
+		pdf.Ln(2)
+	}
+
 	if len(historyComments) > 0 {
 		pdf.SetFont("Arial", "B", 12)
 		pdf.CellFormat(0, 7, "Riwayat Komentar", "", 1, "", false, 0, "")
@@ -1114,3 +1459,1070 @@ func (s *service) ExportById(ctx *abstraction.Context, payload *dto.RequestExpor
 
 	return filename, &buf, "pdf", nil
 }
+
+func (s *service) FindTransition(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	dataStatus, err := s.StatusRepository.Find(ctx, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	statusName := make(map[int]string)
+	for _, v := range dataStatus {
+		statusName[v.ID] = v.Name
+	}
+
+	for _, t := range statusTransitions {
+		res = append(res, map[string]interface{}{
+			"from": map[string]interface{}{
+				"id":   t.From,
+				"name": statusName[t.From],
+			},
+			"to": map[string]interface{}{
+				"id":   t.To,
+				"name": statusName[t.To],
+			},
+			"action":         t.Action,
+			"role_ids":       t.Roles,
+			"require_reason": t.RequireReason,
+		})
+	}
+
+	return map[string]interface{}{
+		"count": len(res),
+		"data":  res,
+	}, nil
+}
+
+func (s *service) FindTransitionById(ctx *abstraction.Context, payload *dto.RequestFindTransitionByIDRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	dataStatus, err := s.StatusRepository.Find(ctx, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	statusName := make(map[int]string)
+	for _, v := range dataStatus {
+		statusName[v.ID] = v.Name
+	}
+
+	for i := range statusTransitions {
+		t := &statusTransitions[i]
+		if t.From != requestData.StatusId {
+			continue
+		}
+		permitted, onBehalfOf, err := roleOnBehalfOf(s, ctx, t.Roles)
+		if err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if !permitted {
+			continue
+		}
+		reason, err := checkStatusGuards(s, ctx, requestData, t)
+		if err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		res = append(res, map[string]interface{}{
+			"to": map[string]interface{}{
+				"id":   t.To,
+				"name": statusName[t.To],
+			},
+			"action":         t.Action,
+			"available":      reason == "",
+			"reason":         reason,
+			"require_reason": t.RequireReason,
+			"on_behalf_of":   onBehalfOf,
+		})
+	}
+
+	return map[string]interface{}{
+		"current_status": map[string]interface{}{
+			"id":   requestData.Status.ID,
+			"name": requestData.Status.Name,
+		},
+		"data": res,
+	}, nil
+}
+
+func (s *service) FindHistory(ctx *abstraction.Context, payload *dto.RequestFindHistoryByIDRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+
+	requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+
+	data, err := s.RequestStatusHistoryRepository.FindByRequestId(ctx, payload.ID, true)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.RequestStatusHistoryRepository.CountByRequestId(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	// durasi dihitung dari perpindahan sebelumnya, atau dari tanggal pengajuan untuk entri pertama
+	prevTime := requestData.CreatedAt
+	for _, v := range data {
+		var fromStatus map[string]interface{} = nil
+		if v.FromStatusId != nil {
+			fromStatus = map[string]interface{}{
+				"id":   v.FromStatus.ID,
+				"name": v.FromStatus.Name,
+			}
+		}
+		var onBehalf map[string]interface{} = nil
+		if v.OnBehalf != nil {
+			onBehalf = map[string]interface{}{
+				"id":   v.OnBehalf.ID,
+				"name": v.OnBehalf.Name,
+			}
+		}
+		res = append(res, map[string]interface{}{
+			"id":          v.ID,
+			"from_status": fromStatus,
+			"to_status": map[string]interface{}{
+				"id":   v.ToStatus.ID,
+				"name": v.ToStatus.Name,
+			},
+			"note": v.Note,
+			"created_by": map[string]interface{}{
+				"id":   v.CreateBy.ID,
+				"name": v.CreateBy.Name,
+				"role": v.CreateBy.Role.Name,
+			},
+			"on_behalf_of":     onBehalf,
+			"duration_seconds": int64(v.CreatedAt.Sub(prevTime).Seconds()),
+			"created_at":       general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		})
+		prevTime = v.CreatedAt
+	}
+
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+func (s *service) Reject(ctx *abstraction.Context, payload *dto.RequestRejectRequest) (map[string]interface{}, error) {
+	if err := s.closeRequest(ctx, payload.ID, constant.STATUS_ID_DITOLAK, payload.Reason, "Event ditolak!"); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success reject!",
+	}, nil
+}
+
+func (s *service) Cancel(ctx *abstraction.Context, payload *dto.RequestCancelRequest) (map[string]interface{}, error) {
+	if err := s.closeRequest(ctx, payload.ID, constant.STATUS_ID_DIBATALKAN, payload.Reason, "Event dibatalkan!"); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success cancel!",
+	}, nil
+}
+
+// closeRequest memindahkan request ke status akhir (ditolak/dibatalkan) beserta alasannya
+func (s *service) closeRequest(ctx *abstraction.Context, id int, statusId int, reason string, title string) error {
+	var (
+		sendNotifTo      []int
+		statusesForAdmin = []int{
+			constant.STATUS_ID_PROSES,
+			constant.STATUS_ID_FINALISASI,
+			constant.STATUS_ID_SELESAI,
+		}
+	)
+	reason = strings.TrimSpace(reason)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := s.RequestRepository.FindById(ctx, id)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+		}
+
+		onBehalfOf, err := validateStatusTransition(s, ctx, requestData, statusId, reason)
+		if err != nil {
+			return err
+		}
+
+		newRequestData := new(model.RequestEntityModel)
+		newRequestData.Context = ctx
+		newRequestData.ID = requestData.ID
+		newRequestData.StatusId = statusId
+		newRequestData.StatusReason = reason
+		result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+
+		if err = createStatusHistory(s, ctx, requestData.ID, &requestData.StatusId, statusId, reason, onBehalfOf); err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		sendNotifTo = append(sendNotifTo, requestData.UserId)
+		if ctx.Auth.RoleID == constant.ROLE_ID_STAF {
+			userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			for _, v := range requestData.HandlerUsers(userBM) {
+				sendNotifTo = append(sendNotifTo, v.ID)
+			}
+		}
+		if slices.Contains(statusesForAdmin, requestData.StatusId) {
+			userAdmin, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_ADMIN, true)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			for _, v := range userAdmin {
+				sendNotifTo = append(sendNotifTo, v.ID)
+			}
+		}
+
+		sendNotifTo = general.RemoveDuplicateArrayInt(sendNotifTo)
+		for i, v := range sendNotifTo {
+			sendNotifTo[i], err = SendNotif(s, ctx, title, fmt.Sprintf("%s: %s", requestData.EventName, reason), v, requestData.ID)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, v := range sendNotifTo {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return nil
+}
+
+func (s *service) Availability(ctx *abstraction.Context, payload *dto.RequestAvailabilityRequest) (map[string]interface{}, error) {
+	parsedEventDateStart, err := general.Parse("2006-01-02 15:04:05", payload.EventDateStart)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse event date start:"+err.Error())
+	}
+	parsedEventDateEnd, err := general.Parse("2006-01-02 15:04:05", payload.EventDateEnd)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse event date end:"+err.Error())
+	}
+	if !parsedEventDateStart.Before(parsedEventDateEnd) {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "Tanggal mulai harus lebih kecil dari tanggal selesai")
+	}
+
+	locationData, err := s.LocationRepository.FindById(ctx, payload.LocationId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if locationData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+	}
+
+	var eventTypeData *model.EventTypeEntityModel = nil
+	if payload.EventTypeId != nil {
+		eventTypeData, err = s.EventTypeRepository.FindById(ctx, *payload.EventTypeId)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if eventTypeData == nil {
+			return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+		}
+	}
+
+	excludeId := 0
+	if payload.ExcludeId != nil {
+		excludeId = *payload.ExcludeId
+	}
+
+	reason, detail, err := checkAvailability(s, ctx, excludeId, locationData, parsedEventDateStart, parsedEventDateEnd, eventTypeData)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	res := map[string]interface{}{
+		"location": map[string]interface{}{
+			"id":         locationData.ID,
+			"name":       locationData.Name,
+			"open_time":  locationData.OpenTime,
+			"close_time": locationData.CloseTime,
+		},
+		"event_date_start": payload.EventDateStart,
+		"event_date_end":   payload.EventDateEnd,
+		"available":        reason == "",
+	}
+	if reason != "" {
+		res["reason"] = reason
+		res["message"] = slotReasonMessage(reason, locationData)
+		for k, v := range detail {
+			res[k] = v
+		}
+	}
+	return res, nil
+}
+
+func (s *service) FindSeries(ctx *abstraction.Context, payload *dto.RequestFindSeriesByIDRequest) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	seriesData, err := s.RequestSeriesRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if seriesData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "series not found")
+	}
+	data, err := s.RequestRepository.FindBySeriesId(ctx, seriesData.ID, 0)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		res = append(res, map[string]interface{}{
+			"id":               v.ID,
+			"series_index":     v.SeriesIndex,
+			"event_name":       v.EventName,
+			"event_location":   v.EventLocation,
+			"event_date_start": general.FormatWithZWithoutChangingTime(v.EventDateStart),
+			"event_date_end":   general.FormatWithZWithoutChangingTime(v.EventDateEnd),
+			"status": map[string]interface{}{
+				"id":   v.Status.ID,
+				"name": v.Status.Name,
+			},
+			"status_reason": v.StatusReason,
+		})
+	}
+	return map[string]interface{}{
+		"id":              seriesData.ID,
+		"recurrence_rule": seriesData.RecurrenceRule,
+		"exdates":         seriesData.Exdates,
+		"count":           len(res),
+		"data":            res,
+	}, nil
+}
+
+// UpdateSeriesStatus menjalankan transisi status ke semua kejadian dalam seri sekaligus,
+// kejadian yang transisinya tidak valid dilewati dan dilaporkan per item
+func (s *service) UpdateSeriesStatus(ctx *abstraction.Context, payload *dto.RequestSeriesStatusRequest) (map[string]interface{}, error) {
+	var (
+		res              []map[string]interface{}
+		sendNotifTo      []int
+		approverIds      []int
+		statusesForAdmin = []int{
+			constant.STATUS_ID_PROSES,
+			constant.STATUS_ID_FINALISASI,
+			constant.STATUS_ID_SELESAI,
+		}
+	)
+	note := strings.TrimSpace(payload.Note)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		seriesData, err := s.RequestSeriesRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if seriesData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "series not found")
+		}
+
+		statusData, err := s.StatusRepository.FindById(ctx, payload.StatusId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if statusData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "status not found")
+		}
+
+		occurrences, err := s.RequestRepository.FindBySeriesId(ctx, seriesData.ID, 0)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		var (
+			updated        int
+			eventName      string
+			requestId      int
+			notifyAdmin    bool
+			handlerRequest *model.RequestEntityModel
+		)
+		for _, v := range occurrences {
+			item := map[string]interface{}{
+				"id":           v.ID,
+				"series_index": v.SeriesIndex,
+				"success":      false,
+			}
+			res = append(res, item)
+			if v.StatusId == payload.StatusId {
+				item["message"] = "already in this status"
+				continue
+			}
+			if isBookingReleased(v.StatusId) {
+				item["message"] = "request is already closed"
+				continue
+			}
+			onBehalfOf, err := validateStatusTransition(s, ctx, v, payload.StatusId, note)
+			if err != nil {
+				metaErr := response.ErrorResponse(err)
+				if metaErr.Code >= http.StatusInternalServerError {
+					return err
+				}
+				item["message"] = metaErr.Message()
+				continue
+			}
+
+			newRequestData := new(model.RequestEntityModel)
+			newRequestData.Context = ctx
+			newRequestData.ID = v.ID
+			newRequestData.StatusId = payload.StatusId
+			if isBookingReleased(payload.StatusId) {
+				newRequestData.StatusReason = note
+			}
+			result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, v.Version)
+			if result.Error != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+			}
+			if result.RowsAffected == 0 {
+				item["message"] = response.ErrorVersionConflict(nil).Message()
+				continue
+			}
+			if err = createStatusHistory(s, ctx, v.ID, &v.StatusId, payload.StatusId, note, onBehalfOf); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if payload.StatusId == constant.STATUS_ID_VALIDASI {
+				userIds, err := startApprovalChain(s, ctx, v)
+				if err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				approverIds = append(approverIds, userIds...)
+			}
+
+			item["success"] = true
+			updated++
+			if requestId == 0 {
+				requestId = v.ID
+				eventName = v.EventName
+				handlerRequest = v
+				sendNotifTo = append(sendNotifTo, v.UserId)
+			}
+			if slices.Contains(statusesForAdmin, v.StatusId) || slices.Contains(statusesForAdmin, payload.StatusId) {
+				notifyAdmin = true
+			}
+		}
+
+		if updated == 0 {
+			return nil
+		}
+		if ctx.Auth.RoleID == constant.ROLE_ID_STAF {
+			userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			for _, v := range handlerRequest.HandlerUsers(userBM) {
+				sendNotifTo = append(sendNotifTo, v.ID)
+			}
+		}
+		if notifyAdmin {
+			userAdmin, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_ADMIN, true)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			for _, v := range userAdmin {
+				sendNotifTo = append(sendNotifTo, v.ID)
+			}
+		}
+
+		sendNotifTo = general.RemoveDuplicateArrayInt(sendNotifTo)
+		for i, v := range sendNotifTo {
+			if v == ctx.Auth.ID {
+				continue
+			}
+			sendNotifTo[i], err = SendNotif(s, ctx, "Event diperbarui!", fmt.Sprintf("%s: %d jadwal menjadi %s", eventName, updated, statusData.Name), v, requestId)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+		sendNotifTo = general.RemoveDuplicateArrayInt(append(sendNotifTo, approverIds...))
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range sendNotifTo {
+		if v == ctx.Auth.ID && !slices.Contains(approverIds, v) {
+			continue
+		}
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success update!",
+		"data":    res,
+	}, nil
+}
+
+// Bulk menerapkan perubahan status, penugasan atau hapus ke banyak request dalam satu transaksi, item yang gagal
+// validasi dilewati dan dicatat di hasil, notifikasi digabung menjadi satu per penerima
+func (s *service) Bulk(ctx *abstraction.Context, payload *dto.RequestBulkRequest) (map[string]interface{}, error) {
+	var (
+		res              []map[string]interface{}
+		sendNotifTo      []int
+		approverIds      []int
+		statusesForAdmin = []int{
+			constant.STATUS_ID_PROSES,
+			constant.STATUS_ID_FINALISASI,
+			constant.STATUS_ID_SELESAI,
+		}
+	)
+	ids := general.RemoveDuplicateArrayInt(payload.Ids)
+	if len(ids) > constant.REQUEST_BULK_MAX {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), fmt.Sprintf("maximum %d requests per bulk action", constant.REQUEST_BULK_MAX))
+	}
+	note := strings.TrimSpace(payload.Note)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		var statusData *model.StatusEntityModel
+		if payload.Action == "status" {
+			var err error
+			statusData, err = s.StatusRepository.FindById(ctx, payload.StatusId)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if statusData == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "status not found")
+			}
+		}
+		var assignee *model.UserEntityModel
+		if payload.Action == "assign" {
+			if ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+			}
+			var err error
+			assignee, err = findAssignee(s, ctx, payload.AssigneeId)
+			if err != nil {
+				return err
+			}
+		}
+
+		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		userAdmin, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_ADMIN, true)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		// penerima -> request yang berubah, agar tiap penerima cukup dapat satu notifikasi
+		var (
+			notifRequest = map[int][]*model.RequestEntityModel{}
+			recipients   []int
+		)
+		addNotif := func(userId int, requestData *model.RequestEntityModel) {
+			if userId == ctx.Auth.ID {
+				return
+			}
+			if _, ok := notifRequest[userId]; !ok {
+				recipients = append(recipients, userId)
+			}
+			notifRequest[userId] = append(notifRequest[userId], requestData)
+		}
+		addUsers := func(users []*model.UserEntityModel, requestData *model.RequestEntityModel) {
+			for _, v := range users {
+				addNotif(v.ID, requestData)
+			}
+		}
+
+		for _, id := range ids {
+			item := map[string]interface{}{
+				"id":      id,
+				"success": false,
+			}
+			res = append(res, item)
+
+			requestData, err := s.RequestRepository.FindById(ctx, id)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if requestData == nil {
+				item["message"] = "request not found"
+				continue
+			}
+
+			newRequestData := new(model.RequestEntityModel)
+			newRequestData.Context = ctx
+			newRequestData.ID = requestData.ID
+			var onBehalfOf *int
+			switch payload.Action {
+			case "status":
+				if requestData.StatusId == payload.StatusId {
+					item["message"] = "already in this status"
+					continue
+				}
+				if isBookingReleased(requestData.StatusId) {
+					item["message"] = "request is already closed"
+					continue
+				}
+				onBehalfOf, err = validateStatusTransition(s, ctx, requestData, payload.StatusId, note)
+				if err != nil {
+					metaErr := response.ErrorResponse(err)
+					if metaErr.Code >= http.StatusInternalServerError {
+						return err
+					}
+					item["message"] = metaErr.Message()
+					continue
+				}
+				newRequestData.StatusId = payload.StatusId
+				if isBookingReleased(payload.StatusId) {
+					newRequestData.StatusReason = note
+				}
+			case "delete":
+				if ctx.Auth.ID != requestData.UserId {
+					item["message"] = "this role is not permitted"
+					continue
+				}
+				newRequestData.IsDelete = true
+				newRequestData.DeletedAt = general.NowWithLocation()
+			case "assign":
+				if !slices.Contains(openStatuses, requestData.StatusId) {
+					item["message"] = "request is not open"
+					continue
+				}
+				newRequestData.AssigneeId = &assignee.ID
+			}
+
+			result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+			if result.Error != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+			}
+			if result.RowsAffected == 0 {
+				item["message"] = response.ErrorVersionConflict(nil).Message()
+				continue
+			}
+			if payload.Action == "status" {
+				if err = createStatusHistory(s, ctx, requestData.ID, &requestData.StatusId, payload.StatusId, note, onBehalfOf); err != nil {
+					return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+				}
+				if payload.StatusId == constant.STATUS_ID_VALIDASI {
+					userIds, err := startApprovalChain(s, ctx, requestData)
+					if err != nil {
+						return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+					}
+					approverIds = append(approverIds, userIds...)
+				}
+			}
+			item["success"] = true
+
+			if payload.Action == "assign" {
+				addNotif(assignee.ID, requestData)
+				continue
+			}
+			// draft belum diajukan ke BM, jadi tidak ada notifikasi
+			if requestData.StatusId == constant.STATUS_ID_DRAFT {
+				continue
+			}
+			forAdmin := slices.Contains(statusesForAdmin, requestData.StatusId) || slices.Contains(statusesForAdmin, newRequestData.StatusId)
+			switch ctx.Auth.RoleID {
+			case constant.ROLE_ID_STAF:
+				addUsers(requestData.HandlerUsers(userBM), requestData)
+				if forAdmin {
+					addUsers(userAdmin, requestData)
+				}
+			case constant.ROLE_ID_BM:
+				addNotif(requestData.UserId, requestData)
+				if forAdmin {
+					addUsers(userAdmin, requestData)
+				}
+			case constant.ROLE_ID_ADMIN:
+				addNotif(requestData.UserId, requestData)
+				addUsers(requestData.HandlerUsers(userBM), requestData)
+			}
+		}
+
+		for _, v := range recipients {
+			requests := notifRequest[v]
+			title, message := "Event diperbarui!", requests[0].EventName
+			switch payload.Action {
+			case "delete":
+				title = "Event dihapus!"
+			case "assign":
+				title = "Event ditugaskan!"
+			}
+			if len(requests) > 1 {
+				switch payload.Action {
+				case "status":
+					message = fmt.Sprintf("%d event menjadi %s", len(requests), statusData.Name)
+				case "delete":
+					message = fmt.Sprintf("%d event dihapus", len(requests))
+				case "assign":
+					message = fmt.Sprintf("%d event ditugaskan", len(requests))
+				}
+			}
+			recipient, err := SendNotif(s, ctx, title, message, v, requests[0].ID)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			sendNotifTo = append(sendNotifTo, recipient)
+		}
+		sendNotifTo = append(sendNotifTo, approverIds...)
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range general.RemoveDuplicateArrayInt(sendNotifTo) {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	countSuccess := 0
+	for _, v := range res {
+		if v["success"] == true {
+			countSuccess++
+		}
+	}
+	return map[string]interface{}{
+		"message":       "success bulk!",
+		"count_success": countSuccess,
+		"count_failed":  len(res) - countSuccess,
+		"data":          res,
+	}, nil
+}
+
+// CreateDraft menyimpan request tanpa validasi lokasi/bentrok dan tanpa notifikasi ke BM
+func (s *service) CreateDraft(ctx *abstraction.Context, payload *dto.RequestCreateDraftRequest) (map[string]interface{}, error) {
+	var (
+		requestId       int
+		allFileUploaded []string
+	)
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_STAF {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		parsedEventDateStart, err := general.Parse("2006-01-02 15:04:05", payload.EventDateStart)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse event date start:"+err.Error())
+		}
+
+		parsedEventDateEnd, err := general.Parse("2006-01-02 15:04:05", payload.EventDateEnd)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse event date end:"+err.Error())
+		}
+
+		eventTypeData, err := s.EventTypeRepository.FindById(ctx, payload.EventTypeId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if eventTypeData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+		}
+
+		modelRequest := &model.RequestEntityModel{
+			Context: ctx,
+			RequestEntity: model.RequestEntity{
+				UserId:           ctx.Auth.ID,
+				EventName:        payload.EventName,
+				EventDateStart:   parsedEventDateStart,
+				EventDateEnd:     parsedEventDateEnd,
+				Description:      payload.Description,
+				EventTypeId:      payload.EventTypeId,
+				CountParticipant: payload.CountParticipant,
+				StatusId:         constant.STATUS_ID_DRAFT,
+				IsDelete:         false,
+			},
+		}
+		if payload.LocationId != nil {
+			locationData, err := s.LocationRepository.FindById(ctx, *payload.LocationId)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if locationData == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location not found")
+			}
+			modelRequest.LocationId = &locationData.ID
+			modelRequest.EventLocation = locationData.Name
+		}
+		if err = s.RequestRepository.Create(ctx, modelRequest).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		requestId = modelRequest.ID
+
+		if err = createStatusHistory(s, ctx, modelRequest.ID, nil, modelRequest.StatusId, "", nil); err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		for _, file := range payload.Files {
+			f, err := file.Open()
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			defer f.Close()
+
+			isFileAvailable, fullFileName := general.ValidateFileUpload(file.Filename)
+			if !isFileAvailable {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), fmt.Sprintf("file format for %s is not approved", file.Filename))
+			}
+
+			newFile, err := gdrive.CreateFile(s.sDrive, fullFileName, "application/octet-stream", f, s.fDrive.Id)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			allFileUploaded = append(allFileUploaded, newFile.Id)
+
+			modelFile := &model.FileEntityModel{
+				Context: ctx,
+				FileEntity: model.FileEntity{
+					RequestId: modelRequest.ID,
+					File:      newFile.Id,
+					FileName:  newFile.Name,
+					IsDelete:  false,
+				},
+			}
+			if err := s.FileRepository.Create(ctx, modelFile).Error; err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		for _, v := range allFileUploaded {
+			errDel := gdrive.DeleteFile(s.sDrive, v)
+			if errDel != nil {
+				logrus.Error("error delete file for error trxmanager:", errDel.Error())
+			}
+		}
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": "success create draft!",
+		"id":      requestId,
+	}, nil
+}
+
+// Submit mengajukan draft ke BM dengan validasi lengkap seperti Create
+func (s *service) Submit(ctx *abstraction.Context, payload *dto.RequestSubmitRequest) (map[string]interface{}, error) {
+	var sendNotifTo []int
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+		}
+		if requestData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+		if requestData.StatusId != constant.STATUS_ID_DRAFT {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not a draft")
+		}
+
+		var missing []string
+		if strings.TrimSpace(requestData.EventName) == "" {
+			missing = append(missing, "event_name")
+		}
+		if requestData.LocationId == nil {
+			missing = append(missing, "location_id")
+		}
+		if strings.TrimSpace(requestData.Description) == "" {
+			missing = append(missing, "description")
+		}
+		if requestData.CountParticipant <= 0 {
+			missing = append(missing, "count_participant")
+		}
+		if len(missing) > 0 {
+			return response.ErrorBuilderWithData(
+				http.StatusBadRequest,
+				errors.New("draft_incomplete"),
+				"Draft belum lengkap",
+				map[string]interface{}{
+					"missing_fields": missing,
+				},
+			)
+		}
+
+		if !requestData.EventDateStart.Before(requestData.EventDateEnd) {
+			return response.ErrorBuilder(
+				http.StatusBadRequest,
+				errors.New("bad_request"),
+				"Tanggal mulai harus lebih kecil dari tanggal selesai",
+			)
+		}
+
+		locationData, err := findBookableLocation(s, ctx, *requestData.LocationId, requestData.CountParticipant)
+		if err != nil {
+			return err
+		}
+
+		if err = checkBookingConflict(s, ctx, requestData.ID, locationData, requestData.EventDateStart, requestData.EventDateEnd, &requestData.EventType); err != nil {
+			return err
+		}
+
+		if err = checkAssetShortage(s, ctx, requestData.ID, requestData.EventDateStart, requestData.EventDateEnd, &requestData.EventType); err != nil {
+			return err
+		}
+
+		assignee, err := pickAssignee(s, ctx, &requestData.EventType)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		newRequestData := new(model.RequestEntityModel)
+		newRequestData.Context = ctx
+		newRequestData.ID = requestData.ID
+		newRequestData.StatusId = constant.STATUS_ID_PENGAJUAN
+		if assignee != nil {
+			newRequestData.AssigneeId = &assignee.ID
+		}
+		result := s.RequestRepository.UpdateWithVersion(ctx, newRequestData, requestData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+
+		if err = createStatusHistory(s, ctx, requestData.ID, &requestData.StatusId, newRequestData.StatusId, "", nil); err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if assignee != nil {
+			userBM = []*model.UserEntityModel{assignee}
+		}
+		for _, v := range userBM {
+			recipient, err := SendNotif(s, ctx, "Event Baru!", requestData.EventName, v.ID, requestData.ID)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			sendNotifTo = append(sendNotifTo, recipient)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range general.RemoveDuplicateArrayInt(sendNotifTo) {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success submit!",
+	}, nil
+}
+
+// Clone membuat request baru dari request lain milik user dengan tanggal baru, cek bentrok tetap lewat Create
+func (s *service) Clone(ctx *abstraction.Context, payload *dto.RequestCloneRequest) (map[string]interface{}, error) {
+	requestData, err := s.RequestRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+	if requestData.UserId != ctx.Auth.ID {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+	}
+
+	newPayload := &dto.RequestCreateRequest{
+		EventName:        requestData.EventName,
+		EventDateStart:   payload.EventDateStart,
+		EventDateEnd:     payload.EventDateEnd,
+		Description:      requestData.Description,
+		EventTypeId:      requestData.EventTypeId,
+		CountParticipant: requestData.CountParticipant,
+	}
+	if requestData.LocationId != nil {
+		newPayload.LocationId = *requestData.LocationId
+	}
+	if payload.EventName != nil {
+		newPayload.EventName = *payload.EventName
+	}
+	if payload.LocationId != nil {
+		newPayload.LocationId = *payload.LocationId
+	}
+	if payload.CountParticipant != nil {
+		newPayload.CountParticipant = *payload.CountParticipant
+	}
+	if newPayload.LocationId == 0 {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location_id is required")
+	}
+
+	if payload.CopyFiles {
+		fileData, err := s.FileRepository.FindByRequestId(ctx, requestData.ID, true)
+		if err != nil && err.Error() != "record not found" {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		for _, v := range fileData {
+			newPayload.CopyFiles = append(newPayload.CopyFiles, dto.RequestCopyFile{
+				File:     v.File,
+				FileName: v.FileName,
+			})
+		}
+	}
+
+	return s.Create(ctx, newPayload)
+}
+
+// ApplyTemplate membuat request baru dari template milik user
+func (s *service) ApplyTemplate(ctx *abstraction.Context, payload *dto.RequestApplyTemplateRequest) (map[string]interface{}, error) {
+	templateData, err := s.RequestTemplateRepository.FindById(ctx, payload.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if templateData == nil || templateData.UserId != ctx.Auth.ID {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "template not found")
+	}
+
+	newPayload := &dto.RequestCreateRequest{
+		EventName:        templateData.EventName,
+		EventDateStart:   payload.EventDateStart,
+		EventDateEnd:     payload.EventDateEnd,
+		Description:      templateData.Description,
+		EventTypeId:      templateData.EventTypeId,
+		CountParticipant: templateData.CountParticipant,
+	}
+	if templateData.LocationId != nil {
+		newPayload.LocationId = *templateData.LocationId
+	}
+	if payload.EventName != nil {
+		newPayload.EventName = *payload.EventName
+	}
+	if payload.LocationId != nil {
+		newPayload.LocationId = *payload.LocationId
+	}
+	if payload.CountParticipant != nil {
+		newPayload.CountParticipant = *payload.CountParticipant
+	}
+	if newPayload.LocationId == 0 {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "location_id is required")
+	}
+
+	return s.Create(ctx, newPayload)
+}
@@ -0,0 +1,377 @@
+package task
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"bm_binus/pkg/ws"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Create(ctx *abstraction.Context, payload *dto.RequestTaskCreateRequest) (map[string]interface{}, error)
+	FindByRequestId(ctx *abstraction.Context, payload *dto.RequestTaskFindByRequestIDRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.RequestTaskDeleteByIDRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.RequestTaskUpdateRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	RequestTaskRepository  repository.RequestTask
+	RequestRepository      repository.Request
+	NotificationRepository repository.Notification
+	UserRepository         repository.User
+	DelegationRepository   repository.Delegation
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		RequestTaskRepository:  f.RequestTaskRepository,
+		RequestRepository:      f.RequestRepository,
+		NotificationRepository: f.NotificationRepository,
+		UserRepository:         f.UserRepository,
+		DelegationRepository:   f.DelegationRepository,
+
+		DB: f.Db,
+	}
+}
+
+// taskStatuses: checklist hanya bisa diubah saat request di fase proses dan finalisasi
+var taskStatuses = []int{
+	constant.STATUS_ID_PROSES,
+	constant.STATUS_ID_FINALISASI,
+}
+
+// SendNotif: lihat repository.SendNotification, mengembalikan id penerima akhir (delegasi jika user sedang cuti)
+func SendNotif(s *service, ctx *abstraction.Context, title string, message string, userId int, requestId int) (int, error) {
+	return repository.SendNotification(ctx, s.NotificationRepository, s.DelegationRepository, title, message, userId, requestId)
+}
+
+func findRequest(s *service, ctx *abstraction.Context, requestId int) (*model.RequestEntityModel, error) {
+	requestData, err := s.RequestRepository.FindById(ctx, requestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if requestData == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+	}
+	return requestData, nil
+}
+
+func findTaskAssignee(s *service, ctx *abstraction.Context, assigneeId int) error {
+	userData, err := s.UserRepository.FindById(ctx, assigneeId)
+	if err != nil && err.Error() != "record not found" {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if userData == nil {
+		return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "assignee not found")
+	}
+	return nil
+}
+
+func touchRequest(s *service, ctx *abstraction.Context, requestId int) error {
+	newRequestData := new(model.RequestEntityModel)
+	newRequestData.Context = ctx
+	newRequestData.ID = requestId
+	newRequestData.UpdatedAt = general.NowLocal()
+	if err := s.RequestRepository.Update(ctx, newRequestData).Error; err != nil {
+		return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	return nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestTaskCreateRequest) (map[string]interface{}, error) {
+	var sendNotifTo []int
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		requestData, err := findRequest(s, ctx, payload.RequestId)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(taskStatuses, requestData.StatusId) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not in proses or finalisasi")
+		}
+		if strings.TrimSpace(payload.Title) == "" {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "title is required")
+		}
+
+		modelTask := &model.RequestTaskEntityModel{
+			Context: ctx,
+			RequestTaskEntity: model.RequestTaskEntity{
+				RequestId:  payload.RequestId,
+				Title:      payload.Title,
+				IsRequired: payload.IsRequired,
+				IsDone:     false,
+				IsDelete:   false,
+			},
+		}
+		if payload.DueDate != nil {
+			parsedDueDate, err := general.Parse("2006-01-02 15:04:05", *payload.DueDate)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse due date:"+err.Error())
+			}
+			modelTask.DueDate = &parsedDueDate
+		}
+		if payload.AssigneeId != nil {
+			if err = findTaskAssignee(s, ctx, *payload.AssigneeId); err != nil {
+				return err
+			}
+			modelTask.AssigneeId = payload.AssigneeId
+		}
+		if err = s.RequestTaskRepository.Create(ctx, modelTask).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		if err = touchRequest(s, ctx, payload.RequestId); err != nil {
+			return err
+		}
+
+		if payload.AssigneeId != nil && *payload.AssigneeId != ctx.Auth.ID {
+			recipient, err := SendNotif(s, ctx, "Tugas baru!", fmt.Sprintf("%s - %s", payload.Title, requestData.EventName), *payload.AssigneeId, requestData.ID)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			sendNotifTo = append(sendNotifTo, recipient)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range sendNotifTo {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) FindByRequestId(ctx *abstraction.Context, payload *dto.RequestTaskFindByRequestIDRequest) (map[string]interface{}, error) {
+	var (
+		res            []map[string]interface{} = nil
+		countDone      int
+		requiredUndone int
+	)
+
+	if _, err := findRequest(s, ctx, payload.RequestId); err != nil {
+		return nil, err
+	}
+
+	data, err := s.RequestTaskRepository.FindByRequestId(ctx, payload.RequestId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	now := general.NowWithLocation()
+	for _, v := range data {
+		var dueDate, doneAt interface{}
+		var assigneeRes, doneByRes map[string]interface{}
+		isOverdue := false
+		if v.DueDate != nil {
+			dueDate = general.FormatWithZWithoutChangingTime(*v.DueDate)
+			isOverdue = !v.IsDone && v.DueDate.Before(*now)
+		}
+		if v.DoneAt != nil {
+			doneAt = general.FormatWithZWithoutChangingTime(*v.DoneAt)
+		}
+		if v.Assignee != nil {
+			assigneeRes = map[string]interface{}{
+				"id":   v.Assignee.ID,
+				"name": v.Assignee.Name,
+			}
+		}
+		if v.DoneByUser != nil {
+			doneByRes = map[string]interface{}{
+				"id":   v.DoneByUser.ID,
+				"name": v.DoneByUser.Name,
+			}
+		}
+		if v.IsDone {
+			countDone++
+		} else if v.IsRequired {
+			requiredUndone++
+		}
+		res = append(res, map[string]interface{}{
+			"id":          v.ID,
+			"request_id":  v.RequestId,
+			"title":       v.Title,
+			"is_required": v.IsRequired,
+			"is_done":     v.IsDone,
+			"is_overdue":  isOverdue,
+			"due_date":    dueDate,
+			"done_at":     doneAt,
+			"assignee":    assigneeRes,
+			"done_by":     doneByRes,
+		})
+	}
+
+	return map[string]interface{}{
+		"count":           len(data),
+		"count_done":      countDone,
+		"required_undone": requiredUndone,
+		"data":            res,
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.RequestTaskDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		taskData, err := s.RequestTaskRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if taskData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "task not found")
+		}
+
+		requestData, err := findRequest(s, ctx, taskData.RequestId)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(taskStatuses, requestData.StatusId) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not in proses or finalisasi")
+		}
+
+		if err = s.RequestTaskRepository.UpdateColumns(ctx, payload.ID, map[string]interface{}{
+			"is_delete": true,
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return touchRequest(s, ctx, taskData.RequestId)
+	}); err != nil {
+		return nil, err
+	}
+
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
+
+// Update: BM dan admin boleh mengubah semua kolom, assignee tugas hanya boleh menandai selesai
+func (s *service) Update(ctx *abstraction.Context, payload *dto.RequestTaskUpdateRequest) (map[string]interface{}, error) {
+	var sendNotifTo []int
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		taskData, err := s.RequestTaskRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if taskData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "task not found")
+		}
+
+		isManager := ctx.Auth.RoleID == constant.ROLE_ID_BM || ctx.Auth.RoleID == constant.ROLE_ID_ADMIN
+		isAssignee := taskData.AssigneeId != nil && *taskData.AssigneeId == ctx.Auth.ID
+		if !isManager {
+			if !isAssignee {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+			}
+			if payload.Title != nil || payload.AssigneeId != nil || payload.DueDate != nil || payload.IsRequired != nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "assignee can only update is_done")
+			}
+		}
+
+		requestData, err := findRequest(s, ctx, taskData.RequestId)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(taskStatuses, requestData.StatusId) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request is not in proses or finalisasi")
+		}
+
+		// pakai map agar nilai false tetap tersimpan
+		columns := make(map[string]interface{})
+		if payload.Title != nil {
+			if strings.TrimSpace(*payload.Title) == "" {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "title is required")
+			}
+			columns["title"] = *payload.Title
+		}
+		if payload.DueDate != nil {
+			parsedDueDate, err := general.Parse("2006-01-02 15:04:05", *payload.DueDate)
+			if err != nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse due date:"+err.Error())
+			}
+			columns["due_date"] = parsedDueDate
+		}
+		if payload.IsRequired != nil {
+			columns["is_required"] = *payload.IsRequired
+		}
+		if payload.AssigneeId != nil && (taskData.AssigneeId == nil || *taskData.AssigneeId != *payload.AssigneeId) {
+			if err = findTaskAssignee(s, ctx, *payload.AssigneeId); err != nil {
+				return err
+			}
+			columns["assignee_id"] = *payload.AssigneeId
+			if *payload.AssigneeId != ctx.Auth.ID {
+				sendNotifTo = append(sendNotifTo, *payload.AssigneeId)
+			}
+		}
+		if payload.IsDone != nil && *payload.IsDone != taskData.IsDone {
+			columns["is_done"] = *payload.IsDone
+			if *payload.IsDone {
+				columns["done_by"] = ctx.Auth.ID
+				columns["done_at"] = general.NowWithLocation()
+			} else {
+				columns["done_by"] = nil
+				columns["done_at"] = nil
+			}
+		}
+		if len(columns) == 0 {
+			return nil
+		}
+		if err = s.RequestTaskRepository.UpdateColumns(ctx, payload.ID, columns).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		if err = touchRequest(s, ctx, taskData.RequestId); err != nil {
+			return err
+		}
+
+		title := taskData.Title
+		if payload.Title != nil {
+			title = *payload.Title
+		}
+		for i, v := range sendNotifTo {
+			if sendNotifTo[i], err = SendNotif(s, ctx, "Tugas baru!", fmt.Sprintf("%s - %s", title, requestData.EventName), v, requestData.ID); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range sendNotifTo {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
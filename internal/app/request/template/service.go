@@ -0,0 +1,150 @@
+package template
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+type Service interface {
+	Find(ctx *abstraction.Context) (map[string]interface{}, error)
+	Create(ctx *abstraction.Context, payload *dto.RequestTemplateCreateRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.RequestTemplateDeleteByIDRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	RequestTemplateRepository repository.RequestTemplate
+	RequestRepository         repository.Request
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		RequestTemplateRepository: f.RequestTemplateRepository,
+		RequestRepository:         f.RequestRepository,
+
+		DB: f.Db,
+	}
+}
+
+func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	data, err := s.RequestTemplateRepository.Find(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.RequestTemplateRepository.Count(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range data {
+		var locationRes map[string]interface{} = nil
+		if v.Location != nil {
+			locationRes = map[string]interface{}{
+				"id":   v.Location.ID,
+				"name": v.Location.Name,
+			}
+		}
+		res = append(res, map[string]interface{}{
+			"id":          v.ID,
+			"name":        v.Name,
+			"event_name":  v.EventName,
+			"location":    locationRes,
+			"description": v.Description,
+			"event_type": map[string]interface{}{
+				"id":   v.EventType.ID,
+				"name": v.EventType.Name,
+			},
+			"count_participant": v.CountParticipant,
+			"created_at":        general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		})
+	}
+
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.RequestTemplateCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_STAF {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		requestData, err := s.RequestRepository.FindById(ctx, payload.RequestId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found")
+		}
+		if requestData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		modelTemplate := &model.RequestTemplateEntityModel{
+			Context: ctx,
+			RequestTemplateEntity: model.RequestTemplateEntity{
+				UserId:           ctx.Auth.ID,
+				Name:             payload.Name,
+				EventName:        requestData.EventName,
+				LocationId:       requestData.LocationId,
+				Description:      requestData.Description,
+				EventTypeId:      requestData.EventTypeId,
+				CountParticipant: requestData.CountParticipant,
+				IsDelete:         false,
+			},
+		}
+		if err = s.RequestTemplateRepository.Create(ctx, modelTemplate).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.RequestTemplateDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		templateData, err := s.RequestTemplateRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if templateData == nil || templateData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "template not found")
+		}
+
+		newTemplateData := new(model.RequestTemplateEntityModel)
+		newTemplateData.Context = ctx
+		newTemplateData.ID = templateData.ID
+		newTemplateData.IsDelete = true
+
+		if err = s.RequestTemplateRepository.Update(ctx, newTemplateData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
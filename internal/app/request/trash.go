@@ -0,0 +1,165 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"bm_binus/pkg/ws"
+	"errors"
+	"net/http"
+)
+
+// FindTrash menampilkan request yang sudah dihapus, staf hanya melihat request miliknya sendiri
+func (s *service) FindTrash(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var (
+		res    []map[string]interface{} = nil
+		userId *int                     = nil
+	)
+	if ctx.Auth.RoleID != constant.ROLE_ID_BM && ctx.Auth.RoleID != constant.ROLE_ID_ADMIN {
+		userId = &ctx.Auth.ID
+	}
+
+	data, err := s.RequestRepository.FindTrash(ctx, userId, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.RequestRepository.CountTrash(ctx, userId)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range data {
+		var deletedAt interface{} = nil
+		if v.DeletedAt != nil {
+			deletedAt = general.FormatWithZWithoutChangingTime(*v.DeletedAt)
+		}
+		res = append(res, map[string]interface{}{
+			"id":               v.ID,
+			"event_name":       v.EventName,
+			"event_location":   v.EventLocation,
+			"event_date_start": general.FormatWithZWithoutChangingTime(v.EventDateStart),
+			"event_date_end":   general.FormatWithZWithoutChangingTime(v.EventDateEnd),
+			"event_type": map[string]interface{}{
+				"id":   v.EventType.ID,
+				"name": v.EventType.Name,
+			},
+			"status": map[string]interface{}{
+				"id":   v.Status.ID,
+				"name": v.Status.Name,
+			},
+			"user": map[string]interface{}{
+				"id":   v.User.ID,
+				"name": v.User.Name,
+			},
+			"series_id":  v.SeriesId,
+			"version":    v.Version,
+			"deleted_at": deletedAt,
+		})
+	}
+
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+// Restore mengembalikan request dari trash. Pemilik dan event type-nya harus masih aktif,
+// request yang masih memegang jadwal dicek ulang lokasi, bentrokan slot serta stok asetnya
+func (s *service) Restore(ctx *abstraction.Context, payload *dto.RequestRestoreRequest) (map[string]interface{}, error) {
+	var sendNotifTo []int
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestData, err := s.RequestRepository.FindDeletedById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if requestData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "request not found in trash")
+		}
+
+		isOwner := requestData.UserId == ctx.Auth.ID
+		isManager := ctx.Auth.RoleID == constant.ROLE_ID_BM || ctx.Auth.RoleID == constant.ROLE_ID_ADMIN
+		if !isOwner && (!isManager || requestData.StatusId == constant.STATUS_ID_DRAFT) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		ownerData, err := s.UserRepository.FindById(ctx, requestData.UserId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if ownerData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "owner of this request is deleted, restore the user first")
+		}
+		eventTypeData, err := s.EventTypeRepository.FindById(ctx, requestData.EventTypeId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if eventTypeData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type of this request is deleted, restore the event type first")
+		}
+
+		if requestData.StatusId != constant.STATUS_ID_DRAFT && !isBookingReleased(requestData.StatusId) {
+			if requestData.LocationId != nil {
+				locationData, err := findBookableLocation(s, ctx, *requestData.LocationId, requestData.CountParticipant)
+				if err != nil {
+					return err
+				}
+				if err = checkBookingConflict(s, ctx, requestData.ID, locationData, requestData.EventDateStart, requestData.EventDateEnd, eventTypeData); err != nil {
+					return err
+				}
+			}
+			if err = checkAssetShortage(s, ctx, requestData.ID, requestData.EventDateStart, requestData.EventDateEnd, eventTypeData); err != nil {
+				return err
+			}
+		}
+
+		result := s.RequestRepository.UpdateColumns(ctx, requestData.ID, map[string]interface{}{
+			"is_delete":  false,
+			"deleted_at": nil,
+			"version":    requestData.Version + 1,
+			"updated_at": general.NowLocal(),
+		})
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+
+		// draft belum diajukan ke BM, jadi tidak ada notifikasi
+		if requestData.StatusId == constant.STATUS_ID_DRAFT {
+			return nil
+		}
+
+		if isOwner {
+			userBM, err := s.UserRepository.FindByRoleIdArr(ctx, constant.ROLE_ID_BM, true)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			for _, v := range requestData.HandlerUsers(userBM) {
+				sendNotifTo = append(sendNotifTo, v.ID)
+			}
+		} else {
+			sendNotifTo = append(sendNotifTo, requestData.UserId)
+		}
+
+		for i, v := range sendNotifTo {
+			if sendNotifTo[i], err = SendNotif(s, ctx, "Event dipulihkan!", requestData.EventName, v, requestData.ID); err != nil {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, v := range general.RemoveDuplicateArrayInt(sendNotifTo) {
+		if err := ws.PublishNotificationWithoutTransaction(v, s.DB, ctx); err != nil {
+			return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+	}
+
+	return map[string]interface{}{
+		"message": "success restore!",
+	}, nil
+}
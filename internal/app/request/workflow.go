@@ -0,0 +1,284 @@
+package request
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// statusGuard mengembalikan pesan alasan jika syarat transisi belum terpenuhi
+type statusGuard func(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) (string, error)
+
+type statusTransition struct {
+	From          int
+	To            int
+	Action        string
+	Roles         []int
+	Guards        []statusGuard
+	RequireReason bool
+}
+
+// statusTransitions: daftar transisi status yang diizinkan beserta role yang boleh menjalankannya
+var statusTransitions = []statusTransition{
+	{
+		From:   constant.STATUS_ID_PENGAJUAN,
+		To:     constant.STATUS_ID_VALIDASI,
+		Action: "Validasi pengajuan",
+		Roles:  []int{constant.ROLE_ID_BM},
+	},
+	{
+		From:   constant.STATUS_ID_VALIDASI,
+		To:     constant.STATUS_ID_PENGAJUAN,
+		Action: "Kembalikan ke pengajuan",
+		Roles:  []int{constant.ROLE_ID_BM},
+	},
+	{
+		From:   constant.STATUS_ID_VALIDASI,
+		To:     constant.STATUS_ID_PROSES,
+		Action: "Proses event",
+		Roles:  []int{constant.ROLE_ID_BM},
+		Guards: []statusGuard{guardApprovalComplete, guardRequiredFiles, guardAssetAvailable},
+	},
+	{
+		From:   constant.STATUS_ID_PROSES,
+		To:     constant.STATUS_ID_FINALISASI,
+		Action: "Finalisasi event",
+		Roles:  []int{constant.ROLE_ID_ADMIN},
+	},
+	{
+		From:   constant.STATUS_ID_FINALISASI,
+		To:     constant.STATUS_ID_PROSES,
+		Action: "Kembalikan ke proses",
+		Roles:  []int{constant.ROLE_ID_ADMIN},
+	},
+	{
+		From:   constant.STATUS_ID_FINALISASI,
+		To:     constant.STATUS_ID_SELESAI,
+		Action: "Selesaikan event",
+		Roles:  []int{constant.ROLE_ID_ADMIN, constant.ROLE_ID_BM},
+	},
+	{
+		From:          constant.STATUS_ID_PENGAJUAN,
+		To:            constant.STATUS_ID_DITOLAK,
+		Action:        "Tolak pengajuan",
+		Roles:         []int{constant.ROLE_ID_BM},
+		RequireReason: true,
+	},
+	{
+		From:          constant.STATUS_ID_VALIDASI,
+		To:            constant.STATUS_ID_DITOLAK,
+		Action:        "Tolak pengajuan",
+		Roles:         []int{constant.ROLE_ID_BM},
+		RequireReason: true,
+	},
+	{
+		From:          constant.STATUS_ID_PROSES,
+		To:            constant.STATUS_ID_DITOLAK,
+		Action:        "Tolak pengajuan",
+		Roles:         []int{constant.ROLE_ID_BM},
+		RequireReason: true,
+	},
+	{
+		From:          constant.STATUS_ID_PENGAJUAN,
+		To:            constant.STATUS_ID_DIBATALKAN,
+		Action:        "Batalkan pengajuan",
+		Roles:         []int{constant.ROLE_ID_STAF},
+		Guards:        []statusGuard{guardRequestOwner},
+		RequireReason: true,
+	},
+	{
+		From:          constant.STATUS_ID_VALIDASI,
+		To:            constant.STATUS_ID_DIBATALKAN,
+		Action:        "Batalkan pengajuan",
+		Roles:         []int{constant.ROLE_ID_STAF},
+		Guards:        []statusGuard{guardRequestOwner},
+		RequireReason: true,
+	},
+	{
+		From:          constant.STATUS_ID_PROSES,
+		To:            constant.STATUS_ID_DIBATALKAN,
+		Action:        "Batalkan pengajuan",
+		Roles:         []int{constant.ROLE_ID_STAF},
+		Guards:        []statusGuard{guardRequestOwner},
+		RequireReason: true,
+	},
+}
+
+// releasedStatuses: status akhir yang tidak lagi memesan tanggal event
+var releasedStatuses = []int{
+	constant.STATUS_ID_DITOLAK,
+	constant.STATUS_ID_DIBATALKAN,
+}
+
+// nonBookingStatuses: status yang tidak ikut dihitung saat cek bentrok jadwal
+var nonBookingStatuses = []int{
+	constant.STATUS_ID_DITOLAK,
+	constant.STATUS_ID_DIBATALKAN,
+	constant.STATUS_ID_DRAFT,
+}
+
+func isBookingReleased(statusId int) bool {
+	return slices.Contains(releasedStatuses, statusId)
+}
+
+func guardRequiredFiles(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) (string, error) {
+	countFile, err := s.FileRepository.CountByRequestId(ctx, requestData.ID)
+	if err != nil && err.Error() != "record not found" {
+		return "", err
+	}
+	if countFile == nil || *countFile == 0 {
+		return "request must have at least one file", nil
+	}
+	return "", nil
+}
+
+func guardRequiredTasks(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) (string, error) {
+	countUndone, err := s.RequestTaskRepository.CountRequiredUndone(ctx, requestData.ID)
+	if err != nil && err.Error() != "record not found" {
+		return "", err
+	}
+	if countUndone != nil && *countUndone > 0 {
+		return fmt.Sprintf("%d required task(s) are not done yet", *countUndone), nil
+	}
+	return "", nil
+}
+
+func guardRequestOwner(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel) (string, error) {
+	if ctx.Auth.ID != requestData.UserId {
+		return "only the owner of the request can do this", nil
+	}
+	return "", nil
+}
+
+func findStatusTransition(from int, to int) *statusTransition {
+	for i := range statusTransitions {
+		if statusTransitions[i].From == from && statusTransitions[i].To == to {
+			return &statusTransitions[i]
+		}
+	}
+	return nil
+}
+
+// checkStatusGuards menjalankan semua guard dan mengembalikan alasan pertama yang gagal
+func checkStatusGuards(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel, transition *statusTransition) (string, error) {
+	for _, guard := range transition.Guards {
+		reason, err := guard(s, ctx, requestData)
+		if err != nil {
+			return "", err
+		}
+		if reason != "" {
+			return reason, nil
+		}
+	}
+	return "", nil
+}
+
+// validateStatusTransition memastikan perpindahan status sesuai workflow, role dan guard.
+// Jika role hanya didapat dari delegasi, id user yang diwakili dikembalikan untuk dicatat di riwayat
+func validateStatusTransition(s *service, ctx *abstraction.Context, requestData *model.RequestEntityModel, to int, reason string) (*int, error) {
+	transition := findStatusTransition(requestData.StatusId, to)
+	if transition == nil {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("invalid_status_transition"), "status transition is not allowed")
+	}
+	permitted, onBehalfOf, err := roleOnBehalfOf(s, ctx, transition.Roles)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if !permitted {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("status_transition_not_permitted"), "this role is not permitted")
+	}
+	if transition.RequireReason && strings.TrimSpace(reason) == "" {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("status_reason_required"), "reason is required for this status")
+	}
+	guardReason, err := checkStatusGuards(s, ctx, requestData, transition)
+	if err != nil {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	if guardReason != "" {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("status_transition_guard_failed"), guardReason)
+	}
+	return onBehalfOf, nil
+}
+
+func createStatusHistory(s *service, ctx *abstraction.Context, requestId int, from *int, to int, note string, onBehalfOf *int) error {
+	var fromStatusId *int = nil
+	if from != nil {
+		f := *from
+		fromStatusId = &f
+	}
+	modelHistory := &model.RequestStatusHistoryEntityModel{
+		Context: ctx,
+		RequestStatusHistoryEntity: model.RequestStatusHistoryEntity{
+			RequestId:    requestId,
+			FromStatusId: fromStatusId,
+			ToStatusId:   to,
+			Note:         note,
+			OnBehalfOf:   onBehalfOf,
+		},
+	}
+	if err := s.RequestStatusHistoryRepository.Create(ctx, modelHistory).Error; err != nil {
+		return err
+	}
+
+	// status berubah, hitungan SLA dimulai ulang dari sekarang
+	if err := s.RequestRepository.UpdateColumns(ctx, requestId, map[string]interface{}{
+		"status_changed_at": general.NowWithLocation(),
+		"is_overdue":        false,
+		"escalated_at":      nil,
+	}).Error; err != nil {
+		return err
+	}
+
+	if to == constant.STATUS_ID_PROSES {
+		return seedRequestTasks(s, ctx, requestId)
+	}
+	return nil
+}
+
+// seedRequestTasks menyalin template checklist event type ke request yang baru masuk proses,
+// dilewati jika request sudah punya tugas (mis. kembali dari finalisasi)
+func seedRequestTasks(s *service, ctx *abstraction.Context, requestId int) error {
+	countTask, err := s.RequestTaskRepository.CountByRequestId(ctx, requestId)
+	if err != nil && err.Error() != "record not found" {
+		return err
+	}
+	if countTask != nil && *countTask > 0 {
+		return nil
+	}
+
+	requestData, err := s.RequestRepository.FindById(ctx, requestId)
+	if err != nil {
+		return err
+	}
+	templates, err := s.ChecklistTemplateRepository.FindByEventTypeId(ctx, requestData.EventTypeId)
+	if err != nil && err.Error() != "record not found" {
+		return err
+	}
+	for _, v := range templates {
+		modelTask := &model.RequestTaskEntityModel{
+			Context: ctx,
+			RequestTaskEntity: model.RequestTaskEntity{
+				RequestId:  requestId,
+				Title:      v.Title,
+				IsRequired: v.IsRequired,
+				IsDone:     false,
+				IsDelete:   false,
+			},
+		}
+		if v.DueDaysBefore != nil {
+			dueDate := requestData.EventDateStart.AddDate(0, 0, -*v.DueDaysBefore)
+			modelTask.DueDate = &dueDate
+		}
+		if err = s.RequestTaskRepository.Create(ctx, modelTask).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
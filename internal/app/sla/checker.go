@@ -0,0 +1,175 @@
+package sla
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/trxmanager"
+	"bm_binus/pkg/ws"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"gorm.io/gorm"
+)
+
+type checker struct {
+	SlaRepository          repository.Sla
+	RequestRepository      repository.Request
+	UserRepository         repository.User
+	NotificationRepository repository.Notification
+	DelegationRepository   repository.Delegation
+
+	DB *gorm.DB
+}
+
+// StartChecker menjalankan pengecekan SLA secara berkala di background sampai ctx selesai
+func StartChecker(ctx context.Context, f *factory.Factory) {
+	c := &checker{
+		SlaRepository:          f.SlaRepository,
+		RequestRepository:      f.RequestRepository,
+		UserRepository:         f.UserRepository,
+		NotificationRepository: f.NotificationRepository,
+		DelegationRepository:   f.DelegationRepository,
+
+		DB: f.Db,
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(constant.SLA_CHECK_INTERVAL_MINUTES) * time.Minute)
+		defer ticker.Stop()
+		for {
+			if err := c.check(); err != nil {
+				logrus.Error("error check sla:", err.Error())
+			}
+			select {
+			case <-ctx.Done():
+				logrus.Println("sla checker is stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// check menandai request yang melewati SLA statusnya sebagai overdue lalu mengirim eskalasi ke BM dan admin,
+// tiap request hanya dieskalasi sekali per status (flag direset saat status berubah)
+func (c *checker) check() error {
+	var (
+		ctx         = &abstraction.Context{}
+		sendNotifTo []int
+	)
+	if err := trxmanager.New(c.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		slaData, err := c.SlaRepository.FindActive(ctx)
+		if err != nil && err.Error() != "record not found" {
+			return err
+		}
+		if len(slaData) == 0 {
+			return nil
+		}
+
+		// SLA per jenis event lebih diutamakan daripada SLA umum status tersebut
+		var (
+			statusIds       []int
+			slaByEventType  = map[string]*model.SlaEntityModel{}
+			slaByStatusOnly = map[int]*model.SlaEntityModel{}
+		)
+		for _, v := range slaData {
+			statusIds = append(statusIds, v.StatusId)
+			if v.EventTypeId != nil {
+				slaByEventType[fmt.Sprintf("%d_%d", v.StatusId, *v.EventTypeId)] = v
+			} else {
+				slaByStatusOnly[v.StatusId] = v
+			}
+		}
+
+		requestData, err := c.RequestRepository.FindSlaCandidate(ctx, general.RemoveDuplicateArrayInt(statusIds))
+		if err != nil && err.Error() != "record not found" {
+			return err
+		}
+
+		var overdue []*model.RequestEntityModel
+		now := general.NowWithLocation()
+		for _, v := range requestData {
+			slaRule, ok := slaByEventType[fmt.Sprintf("%d_%d", v.StatusId, v.EventTypeId)]
+			if !ok {
+				slaRule, ok = slaByStatusOnly[v.StatusId]
+			}
+			if !ok {
+				continue
+			}
+			since := v.CreatedAt
+			if v.StatusChangedAt != nil {
+				since = *v.StatusChangedAt
+			}
+			if !now.After(since.Add(time.Duration(slaRule.DurationHours) * time.Hour)) {
+				continue
+			}
+
+			if err = c.RequestRepository.UpdateColumns(ctx, v.ID, map[string]interface{}{
+				"is_overdue":   true,
+				"escalated_at": now,
+			}).Error; err != nil {
+				return err
+			}
+			overdue = append(overdue, v)
+		}
+		if len(overdue) == 0 {
+			return nil
+		}
+
+		var recipientIds []int
+		for _, roleId := range []int{constant.ROLE_ID_BM, constant.ROLE_ID_ADMIN} {
+			users, err := c.UserRepository.FindByRoleId(ctx, roleId)
+			if err != nil && err.Error() != "record not found" {
+				return err
+			}
+			for _, u := range users {
+				// user yang sedang cuti digantikan oleh delegasinya
+				delegation, err := c.DelegationRepository.FindActiveByUserId(ctx, u.ID, *general.NowWithLocation())
+				if err != nil && err.Error() != "record not found" {
+					return err
+				}
+				if delegation != nil {
+					recipientIds = append(recipientIds, delegation.DelegateId)
+					continue
+				}
+				recipientIds = append(recipientIds, u.ID)
+			}
+		}
+		recipientIds = general.RemoveDuplicateArrayInt(recipientIds)
+		for _, v := range overdue {
+			for _, u := range recipientIds {
+				modelNotification := &model.NotificationEntityModel{
+					Context: ctx,
+					NotificationEntity: model.NotificationEntity{
+						Title:     "Event melewati SLA!",
+						Message:   fmt.Sprintf("%s sudah melewati batas waktu status %s", v.EventName, v.Status.Name),
+						IsRead:    false,
+						UserId:    u,
+						RequestId: v.ID,
+					},
+				}
+				if err = c.NotificationRepository.Create(ctx, modelNotification).Error; err != nil {
+					return err
+				}
+				sendNotifTo = append(sendNotifTo, u)
+			}
+		}
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	for _, v := range general.RemoveDuplicateArrayInt(sendNotifTo) {
+		if err := ws.PublishNotificationWithoutTransaction(v, c.DB, ctx); err != nil {
+			return err
+		}
+	}
+	return nil
+}
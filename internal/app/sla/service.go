@@ -0,0 +1,210 @@
+package sla
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/dto"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/model"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/response"
+	"bm_binus/pkg/util/trxmanager"
+	"errors"
+	"net/http"
+	"slices"
+
+	"gorm.io/gorm"
+)
+
+// slaStatuses: SLA hanya berlaku untuk status yang masih menunggu tindakan
+var slaStatuses = []int{
+	constant.STATUS_ID_PENGAJUAN,
+	constant.STATUS_ID_VALIDASI,
+	constant.STATUS_ID_PROSES,
+	constant.STATUS_ID_FINALISASI,
+}
+
+type Service interface {
+	Find(ctx *abstraction.Context) (map[string]interface{}, error)
+	Create(ctx *abstraction.Context, payload *dto.SlaCreateRequest) (map[string]interface{}, error)
+	Update(ctx *abstraction.Context, payload *dto.SlaUpdateRequest) (map[string]interface{}, error)
+	Delete(ctx *abstraction.Context, payload *dto.SlaDeleteByIDRequest) (map[string]interface{}, error)
+}
+
+type service struct {
+	SlaRepository       repository.Sla
+	StatusRepository    repository.Status
+	EventTypeRepository repository.EventType
+
+	DB *gorm.DB
+}
+
+func NewService(f *factory.Factory) Service {
+	return &service{
+		SlaRepository:       f.SlaRepository,
+		StatusRepository:    f.StatusRepository,
+		EventTypeRepository: f.EventTypeRepository,
+
+		DB: f.Db,
+	}
+}
+
+func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	data, err := s.SlaRepository.Find(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.SlaRepository.Count(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+
+	for _, v := range data {
+		var eventTypeRes map[string]interface{} = nil
+		if v.EventType != nil {
+			eventTypeRes = map[string]interface{}{
+				"id":   v.EventType.ID,
+				"name": v.EventType.Name,
+			}
+		}
+		res = append(res, map[string]interface{}{
+			"id": v.ID,
+			"status": map[string]interface{}{
+				"id":   v.Status.ID,
+				"name": v.Status.Name,
+			},
+			"event_type":     eventTypeRes,
+			"duration_hours": v.DurationHours,
+			"created_at":     general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		})
+	}
+
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+func (s *service) Create(ctx *abstraction.Context, payload *dto.SlaCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		if !slices.Contains(slaStatuses, payload.StatusId) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "SLA can only be set for open statuses")
+		}
+		statusData, err := s.StatusRepository.FindById(ctx, payload.StatusId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if statusData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "status not found")
+		}
+		if payload.EventTypeId != nil {
+			eventTypeData, err := s.EventTypeRepository.FindById(ctx, *payload.EventTypeId)
+			if err != nil && err.Error() != "record not found" {
+				return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+			}
+			if eventTypeData == nil {
+				return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "event type not found")
+			}
+		}
+
+		existing, err := s.SlaRepository.FindByStatusAndEventType(ctx, payload.StatusId, payload.EventTypeId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if existing != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "SLA for this status and event type already exists")
+		}
+
+		modelSla := &model.SlaEntityModel{
+			Context: ctx,
+			SlaEntity: model.SlaEntity{
+				StatusId:      payload.StatusId,
+				EventTypeId:   payload.EventTypeId,
+				DurationHours: payload.DurationHours,
+				IsDelete:      false,
+			},
+		}
+		if err = s.SlaRepository.Create(ctx, modelSla).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) Update(ctx *abstraction.Context, payload *dto.SlaUpdateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		slaData, err := s.SlaRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if slaData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "sla not found")
+		}
+
+		newSlaData := new(model.SlaEntityModel)
+		newSlaData.Context = ctx
+		newSlaData.ID = payload.ID
+		if payload.DurationHours != nil {
+			newSlaData.DurationHours = *payload.DurationHours
+		}
+
+		if err = s.SlaRepository.Update(ctx, newSlaData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success update!",
+	}, nil
+}
+
+func (s *service) Delete(ctx *abstraction.Context, payload *dto.SlaDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		slaData, err := s.SlaRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if slaData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "sla not found")
+		}
+
+		newSlaData := new(model.SlaEntityModel)
+		newSlaData.Context = ctx
+		newSlaData.ID = slaData.ID
+		newSlaData.IsDelete = true
+
+		if err = s.SlaRepository.Update(ctx, newSlaData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
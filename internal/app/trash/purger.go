@@ -0,0 +1,119 @@
+package trash
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/config"
+	"bm_binus/internal/factory"
+	"bm_binus/internal/repository"
+	"bm_binus/pkg/constant"
+	"bm_binus/pkg/gdrive"
+	"bm_binus/pkg/util/general"
+	"bm_binus/pkg/util/trxmanager"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/api/drive/v3"
+	"gorm.io/gorm"
+)
+
+type purger struct {
+	TrashRepository repository.Trash
+
+	DB     *gorm.DB
+	sDrive *drive.Service
+}
+
+// StartPurger menghapus permanen data di trash yang sudah melewati masa simpan secara berkala sampai ctx selesai.
+// User dan event type tidak ikut di-purge karena masih direferensikan riwayat request
+func StartPurger(ctx context.Context, f *factory.Factory) {
+	p := &purger{
+		TrashRepository: f.TrashRepository,
+
+		DB:     f.Db,
+		sDrive: f.GDrive.Service,
+	}
+
+	go func() {
+		ticker := time.NewTicker(time.Duration(constant.TRASH_PURGE_INTERVAL_HOURS) * time.Hour)
+		defer ticker.Stop()
+		for {
+			if err := p.purge(); err != nil {
+				logrus.Error("error purge trash:", err.Error())
+			}
+			select {
+			case <-ctx.Done():
+				logrus.Println("trash purger is stopped")
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// purge menghapus request (beserta turunannya), komentar dan file yang dihapus lebih dari RetentionDays hari lalu.
+// Berkas drive baru dihapus setelah transaksi sukses dan hanya jika id drive-nya tidak dipakai baris file lain
+func (p *purger) purge() error {
+	var (
+		ctx      = &abstraction.Context{}
+		before   = general.NowWithLocation().AddDate(0, 0, -config.Get().Trash.RetentionDays)
+		driveIds []string
+	)
+	if err := trxmanager.New(p.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		requestIds, err := p.TrashRepository.FindExpiredRequestIds(ctx, before)
+		if err != nil && err.Error() != "record not found" {
+			return err
+		}
+
+		fileData, err := p.TrashRepository.FindExpiredFiles(ctx, before, requestIds)
+		if err != nil && err.Error() != "record not found" {
+			return err
+		}
+		var fileIds []int
+		for _, v := range fileData {
+			fileIds = append(fileIds, v.ID)
+			if !slices.Contains(driveIds, v.File) {
+				driveIds = append(driveIds, v.File)
+			}
+		}
+
+		if len(fileIds) > 0 {
+			if err = p.TrashRepository.PurgeFiles(ctx, fileIds).Error; err != nil {
+				return err
+			}
+		}
+		if len(requestIds) > 0 {
+			if err = p.TrashRepository.PurgeRequests(ctx, requestIds); err != nil {
+				return err
+			}
+		}
+		if err = p.TrashRepository.PurgeComments(ctx, before).Error; err != nil {
+			return err
+		}
+
+		if len(requestIds) > 0 || len(fileIds) > 0 {
+			logrus.Printf("trash purged: %d request, %d file", len(requestIds), len(fileIds))
+		}
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if len(driveIds) == 0 {
+		return nil
+	}
+	referenced, err := p.TrashRepository.FindReferencedDriveIds(ctx, driveIds)
+	if err != nil && err.Error() != "record not found" {
+		return err
+	}
+	for _, v := range driveIds {
+		if slices.Contains(referenced, v) {
+			continue
+		}
+		if err := gdrive.DeleteFile(p.sDrive, v); err != nil {
+			logrus.Error("error delete drive file ", v, ":", err.Error())
+		}
+	}
+	return nil
+}
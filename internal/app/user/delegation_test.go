@@ -0,0 +1,26 @@
+package contact
+
+import (
+	"bm_binus/pkg/constant"
+	"testing"
+)
+
+func TestCanDelegateTo(t *testing.T) {
+	cases := []struct {
+		delegator int
+		delegate  int
+		want      bool
+	}{
+		{constant.ROLE_ID_BM, constant.ROLE_ID_BM, true},
+		{constant.ROLE_ID_BM, constant.ROLE_ID_ADMIN, true},
+		{constant.ROLE_ID_BM, constant.ROLE_ID_STAF, false},
+		{constant.ROLE_ID_ADMIN, constant.ROLE_ID_BM, true},
+		{constant.ROLE_ID_ADMIN, constant.ROLE_ID_STAF, false},
+		{constant.ROLE_ID_STAF, constant.ROLE_ID_STAF, true},
+	}
+	for _, c := range cases {
+		if got := canDelegateTo(c.delegator, c.delegate); got != c.want {
+			t.Errorf("canDelegateTo(%d, %d) = %v, want %v", c.delegator, c.delegate, got, c.want)
+		}
+	}
+}
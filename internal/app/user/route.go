@@ -15,4 +15,9 @@ func (h *handler) Route(v *echo.Group) {
 	v.PATCH("/change-password/:id", h.ChangePassword, middleware.Authentication)
 	v.GET("/export", h.Export, middleware.Authentication)
 	v.GET("/info", h.Info, middleware.Authentication)
+	v.GET("/delegation", h.FindDelegation, middleware.Authentication)
+	v.POST("/delegation", h.CreateDelegation, middleware.Authentication)
+	v.DELETE("/delegation/:id", h.DeleteDelegation, middleware.Authentication)
+	v.GET("/trash", h.FindTrash, middleware.Authentication)
+	v.PATCH("/:id/restore", h.Restore, middleware.Authentication)
 }
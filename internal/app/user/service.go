@@ -14,6 +14,7 @@ import (
 	"bytes"
 	"fmt"
 	"net/http"
+	"slices"
 	"strings"
 	"unicode/utf8"
 
@@ -34,11 +35,29 @@ type Service interface {
 	ChangePassword(ctx *abstraction.Context, payload *dto.UserChangePasswordRequest) (map[string]interface{}, error)
 	Export(ctx *abstraction.Context, payload *dto.UserExportRequest) (string, *bytes.Buffer, string, error)
 	Info(ctx *abstraction.Context) (map[string]interface{}, error)
+	FindDelegation(ctx *abstraction.Context) (map[string]interface{}, error)
+	CreateDelegation(ctx *abstraction.Context, payload *dto.UserDelegationCreateRequest) (map[string]interface{}, error)
+	DeleteDelegation(ctx *abstraction.Context, payload *dto.UserDelegationDeleteByIDRequest) (map[string]interface{}, error)
+	FindTrash(ctx *abstraction.Context) (map[string]interface{}, error)
+	Restore(ctx *abstraction.Context, payload *dto.UserRestoreRequest) (map[string]interface{}, error)
+}
+
+// delegateRoles: role yang boleh menerima delegasi dari role tertentu. Delegasi mewarisi semua hak role pemberi
+// delegasi, jadi hak BM/admin tidak boleh berpindah ke staf. Role yang tidak terdaftar boleh didelegasikan ke siapa saja
+var delegateRoles = map[int][]int{
+	constant.ROLE_ID_BM:    {constant.ROLE_ID_BM, constant.ROLE_ID_ADMIN},
+	constant.ROLE_ID_ADMIN: {constant.ROLE_ID_ADMIN, constant.ROLE_ID_BM},
+}
+
+func canDelegateTo(delegatorRoleId int, delegateRoleId int) bool {
+	roles, ok := delegateRoles[delegatorRoleId]
+	return !ok || slices.Contains(roles, delegateRoleId)
 }
 
 type service struct {
-	UserRepository repository.User
-	RoleRepository repository.Role
+	UserRepository       repository.User
+	RoleRepository       repository.Role
+	DelegationRepository repository.Delegation
 
 	DB      *gorm.DB
 	DbRedis *redis.Client
@@ -46,8 +65,9 @@ type service struct {
 
 func NewService(f *factory.Factory) Service {
 	return &service{
-		UserRepository: f.UserRepository,
-		RoleRepository: f.RoleRepository,
+		UserRepository:       f.UserRepository,
+		RoleRepository:       f.RoleRepository,
+		DelegationRepository: f.DelegationRepository,
 
 		DB:      f.Db,
 		DbRedis: f.DbRedis,
@@ -134,6 +154,7 @@ func (s *service) Find(ctx *abstraction.Context) (map[string]interface{}, error)
 			"id":         v.ID,
 			"name":       v.Name,
 			"email":      v.Email,
+			"version":    v.Version,
 			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
 			"updated_at": general.FormatWithZWithoutChangingTime(*v.UpdatedAt),
 			"role": map[string]interface{}{
@@ -155,26 +176,32 @@ func (s *service) FindById(ctx *abstraction.Context, payload *dto.UserFindByIDRe
 		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
 	}
 	if data != nil {
-		res = map[string]interface{}{
-			"id":         data.ID,
-			"name":       data.Name,
-			"email":      data.Email,
-			"is_delete":  data.IsDelete,
-			"created_at": general.FormatWithZWithoutChangingTime(data.CreatedAt),
-			"updated_at": general.FormatWithZWithoutChangingTime(*data.UpdatedAt),
-			"role": map[string]interface{}{
-				"id":   data.Role.ID,
-				"name": data.Role.Name,
-			},
-		}
-
+		res = userDetail(data)
 	}
 	return map[string]interface{}{
 		"data": res,
 	}, nil
 }
 
+// userDetail: bentuk response detail user, dipakai FindById dan data terbaru saat version bentrok
+func userDetail(data *model.UserEntityModel) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         data.ID,
+		"name":       data.Name,
+		"email":      data.Email,
+		"is_delete":  data.IsDelete,
+		"version":    data.Version,
+		"created_at": general.FormatWithZWithoutChangingTime(data.CreatedAt),
+		"updated_at": general.FormatWithZWithoutChangingTime(*data.UpdatedAt),
+		"role": map[string]interface{}{
+			"id":   data.Role.ID,
+			"name": data.Role.Name,
+		},
+	}
+}
+
 func (s *service) Update(ctx *abstraction.Context, payload *dto.UserUpdateRequest) (map[string]interface{}, error) {
+	var newVersion int
 	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
 		userData, err := s.UserRepository.FindById(ctx, payload.ID)
 		if err != nil && err.Error() != "record not found" {
@@ -184,6 +211,14 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.UserUpdateReques
 			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "user not found")
 		}
 
+		version, err := general.ProcessVersion(ctx, payload.Version)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), err.Error())
+		}
+		if version != nil && *version != userData.Version {
+			return response.ErrorVersionConflict(userDetail(userData))
+		}
+
 		newUserData := new(model.UserEntityModel)
 		newUserData.Context = ctx
 		newUserData.ID = payload.ID
@@ -204,9 +239,14 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.UserUpdateReques
 			newUserData.RoleId = *payload.RoleId
 		}
 
-		if err = s.UserRepository.Update(ctx, newUserData).Error; err != nil {
-			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		result := s.UserRepository.UpdateWithVersion(ctx, newUserData, userData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
 		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
+		}
+		newVersion = newUserData.Version
 
 		return nil
 	}); err != nil {
@@ -214,6 +254,7 @@ func (s *service) Update(ctx *abstraction.Context, payload *dto.UserUpdateReques
 	}
 	return map[string]interface{}{
 		"message": "success update!",
+		"version": newVersion,
 	}, nil
 }
 
@@ -235,9 +276,14 @@ func (s *service) Delete(ctx *abstraction.Context, payload *dto.UserDeleteByIDRe
 		newUserData.Context = ctx
 		newUserData.ID = userData.ID
 		newUserData.IsDelete = true
+		newUserData.DeletedAt = general.NowWithLocation()
 
-		if err = s.UserRepository.Update(ctx, newUserData).Error; err != nil {
-			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		result := s.UserRepository.UpdateWithVersion(ctx, newUserData, userData.Version)
+		if result.Error != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, result.Error, "server_error")
+		}
+		if result.RowsAffected == 0 {
+			return response.ErrorVersionConflict(nil)
 		}
 
 		userLoginFrom := general.GetRedisUUIDArray(s.DbRedis, general.GenerateRedisKeyUserLogin(userData.ID))
@@ -482,3 +528,215 @@ func (s *service) Info(ctx *abstraction.Context) (map[string]interface{}, error)
 		"data": res,
 	}, nil
 }
+
+// FindDelegation menampilkan delegasi yang dibuat user login maupun yang ditujukan kepadanya
+func (s *service) FindDelegation(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	data, err := s.DelegationRepository.FindByUserId(ctx, ctx.Auth.ID)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	now := general.NowWithLocation()
+	for _, v := range data {
+		res = append(res, map[string]interface{}{
+			"id": v.ID,
+			"user": map[string]interface{}{
+				"id":   v.User.ID,
+				"name": v.User.Name,
+			},
+			"delegate": map[string]interface{}{
+				"id":   v.Delegate.ID,
+				"name": v.Delegate.Name,
+			},
+			"date_start": general.FormatWithZWithoutChangingTime(v.DateStart),
+			"date_end":   general.FormatWithZWithoutChangingTime(v.DateEnd),
+			"reason":     v.Reason,
+			"is_active":  !v.DateStart.After(*now) && v.DateEnd.After(*now),
+			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
+		})
+	}
+	return map[string]interface{}{
+		"count": len(res),
+		"data":  res,
+	}, nil
+}
+
+func (s *service) CreateDelegation(ctx *abstraction.Context, payload *dto.UserDelegationCreateRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if payload.DelegateId == ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "cannot delegate to yourself")
+		}
+		delegateData, err := s.UserRepository.FindById(ctx, payload.DelegateId)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if delegateData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "delegate not found")
+		}
+		if !canDelegateTo(ctx.Auth.RoleID, delegateData.RoleId) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("delegate_role_not_permitted"), "delegate role is not permitted to take over your duties")
+		}
+
+		parsedDateStart, err := general.Parse("2006-01-02 15:04:05", payload.DateStart)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse date start:"+err.Error())
+		}
+		parsedDateEnd, err := general.Parse("2006-01-02 15:04:05", payload.DateEnd)
+		if err != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "err parse date end:"+err.Error())
+		}
+		if !parsedDateStart.Before(parsedDateEnd) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "Tanggal mulai harus lebih kecil dari tanggal selesai")
+		}
+		if !parsedDateEnd.After(*general.NowWithLocation()) {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "date end must be in the future")
+		}
+
+		overlap, err := s.DelegationRepository.FindOverlap(ctx, ctx.Auth.ID, parsedDateStart, parsedDateEnd)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if len(overlap) > 0 {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("delegation_overlap"), "you already have a delegation in this period")
+		}
+		// delegasi tidak berantai, delegasi yang juga sedang cuti pada periode ini tidak bisa dipilih
+		overlap, err = s.DelegationRepository.FindOverlap(ctx, payload.DelegateId, parsedDateStart, parsedDateEnd)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if len(overlap) > 0 {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("delegation_overlap"), "delegate is also delegating in this period")
+		}
+
+		modelDelegation := &model.DelegationEntityModel{
+			Context: ctx,
+			DelegationEntity: model.DelegationEntity{
+				UserId:     ctx.Auth.ID,
+				DelegateId: payload.DelegateId,
+				DateStart:  parsedDateStart,
+				DateEnd:    parsedDateEnd,
+				Reason:     strings.TrimSpace(payload.Reason),
+				IsDelete:   false,
+			},
+		}
+		if err = s.DelegationRepository.Create(ctx, modelDelegation).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success create!",
+	}, nil
+}
+
+func (s *service) DeleteDelegation(ctx *abstraction.Context, payload *dto.UserDelegationDeleteByIDRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		delegationData, err := s.DelegationRepository.FindById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if delegationData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "delegation not found")
+		}
+		if delegationData.UserId != ctx.Auth.ID {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		newDelegationData := new(model.DelegationEntityModel)
+		newDelegationData.Context = ctx
+		newDelegationData.ID = delegationData.ID
+		newDelegationData.IsDelete = true
+		if err = s.DelegationRepository.Update(ctx, newDelegationData).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success delete!",
+	}, nil
+}
+
+func (s *service) FindTrash(ctx *abstraction.Context) (map[string]interface{}, error) {
+	var res []map[string]interface{} = nil
+	if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+		return nil, response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+	}
+
+	data, err := s.UserRepository.FindTrash(ctx, false)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	count, err := s.UserRepository.CountTrash(ctx)
+	if err != nil && err.Error() != "record not found" {
+		return nil, response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+	}
+	for _, v := range data {
+		var deletedAt interface{} = nil
+		if v.DeletedAt != nil {
+			deletedAt = general.FormatWithZWithoutChangingTime(*v.DeletedAt)
+		}
+		res = append(res, map[string]interface{}{
+			"id":         v.ID,
+			"name":       v.Name,
+			"email":      v.Email,
+			"version":    v.Version,
+			"created_at": general.FormatWithZWithoutChangingTime(v.CreatedAt),
+			"deleted_at": deletedAt,
+			"role": map[string]interface{}{
+				"id":   v.Role.ID,
+				"name": v.Role.Name,
+			},
+		})
+	}
+	return map[string]interface{}{
+		"count": count,
+		"data":  res,
+	}, nil
+}
+
+// Restore: email user yang dikembalikan tidak boleh sudah dipakai user aktif lain
+func (s *service) Restore(ctx *abstraction.Context, payload *dto.UserRestoreRequest) (map[string]interface{}, error) {
+	if err := trxmanager.New(s.DB).WithTrx(ctx, func(ctx *abstraction.Context) error {
+		if ctx.Auth.RoleID != constant.ROLE_ID_BM {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "this role is not permitted")
+		}
+
+		userData, err := s.UserRepository.FindDeletedById(ctx, payload.ID)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if userData == nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "user not found in trash")
+		}
+
+		userEmail, err := s.UserRepository.FindByEmail(ctx, userData.Email)
+		if err != nil && err.Error() != "record not found" {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+		if userEmail != nil {
+			return response.ErrorBuilder(http.StatusBadRequest, errors.New("bad_request"), "email already used by another user")
+		}
+
+		if err = s.UserRepository.UpdateColumns(ctx, payload.ID, map[string]interface{}{
+			"is_delete":  false,
+			"deleted_at": nil,
+			"version":    userData.Version + 1,
+			"updated_at": general.NowLocal(),
+		}).Error; err != nil {
+			return response.ErrorBuilder(http.StatusInternalServerError, err, "server_error")
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{
+		"message": "success restore!",
+	}, nil
+}
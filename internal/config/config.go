@@ -4,6 +4,7 @@ import (
 	"bm_binus/pkg/constant"
 	"fmt"
 	"os"
+	"strconv"
 	"sync"
 
 	"github.com/joho/godotenv"
@@ -17,6 +18,7 @@ type Configuration struct {
 	JWT     JWT
 	Gomail  Gomail
 	Drive   Drive
+	Trash   Trash
 }
 
 type App struct {
@@ -65,6 +67,10 @@ type Drive struct {
 	RefreshTokenDrive string
 }
 
+type Trash struct {
+	RetentionDays int
+}
+
 var lock = &sync.Mutex{}
 var defaultConfig Configuration
 
@@ -117,5 +123,11 @@ func Init() *Configuration {
 	defaultConfig.Drive.CredentialsDrive = os.Getenv("CREDENTIALS_DRIVE")
 	defaultConfig.Drive.RefreshTokenDrive = os.Getenv("REFRESH_DRIVE")
 
+	// data di trash dihapus permanen setelah TRASH_RETENTION_DAYS hari, default constant.TRASH_RETENTION_DAYS
+	defaultConfig.Trash.RetentionDays = constant.TRASH_RETENTION_DAYS
+	if retentionDays, err := strconv.Atoi(os.Getenv("TRASH_RETENTION_DAYS")); err == nil && retentionDays > 0 {
+		defaultConfig.Trash.RetentionDays = retentionDays
+	}
+
 	return &defaultConfig
 }
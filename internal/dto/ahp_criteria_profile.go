@@ -0,0 +1,24 @@
+package dto
+
+type AhpCriteriaProfileCreateRequest struct {
+	Name       string                 `json:"name" validate:"required"`
+	Comparison []AhpComparisonRequest `json:"comparison" validate:"required"`
+}
+
+type AhpCriteriaProfileFindByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type AhpCriteriaProfileUpdateRequest struct {
+	ID         int                    `param:"id" validate:"required"`
+	Name       *string                `json:"name"`
+	Comparison []AhpComparisonRequest `json:"comparison"`
+}
+
+type AhpCriteriaProfileActivateRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type AhpCriteriaProfileDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
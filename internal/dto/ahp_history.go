@@ -6,6 +6,7 @@ type AhpHistoryCreateRequest struct {
 	Alternatif           []string                          `json:"alternatif" validate:"required"`
 	AlternatifComparison map[string][]AhpComparisonRequest `json:"alternatif_comparison" validate:"required"`
 	ReferenceRequest     int                               `json:"reference_request" validate:"required"`
+	Method               *string                           `json:"method"`
 }
 
 type AhpComparisonRequest struct {
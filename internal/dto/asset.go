@@ -0,0 +1,31 @@
+package dto
+
+type AssetCreateRequest struct {
+	Name       string `json:"name" form:"name" validate:"required"`
+	ItemType   string `json:"item_type" form:"item_type" validate:"required"`
+	Quantity   int    `json:"quantity" form:"quantity" validate:"min=0"`
+	LocationId *int   `json:"location_id" form:"location_id"`
+	IsActive   *bool  `json:"is_active" form:"is_active"`
+}
+
+type AssetFindByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type AssetUpdateRequest struct {
+	ID         int     `param:"id" validate:"required"`
+	Name       *string `json:"name" form:"name"`
+	ItemType   *string `json:"item_type" form:"item_type"`
+	Quantity   *int    `json:"quantity" form:"quantity" validate:"omitempty,min=0"`
+	LocationId *int    `json:"location_id" form:"location_id"`
+	IsActive   *bool   `json:"is_active" form:"is_active"`
+}
+
+type AssetDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type AssetAvailabilityRequest struct {
+	DateStart string `query:"date_start" validate:"required"`
+	DateEnd   string `query:"date_end" validate:"required"`
+}
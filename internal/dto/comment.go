@@ -13,6 +13,14 @@ type CommentDeleteByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
 
+type CommentFindTrashRequest struct {
+	RequestId int `param:"request_id" validate:"required"`
+}
+
+type CommentRestoreRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
 type CommentUpdateRequest struct {
 	ID      int     `param:"id" validate:"required"`
 	Comment *string `json:"comment" form:"comment"`
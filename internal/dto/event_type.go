@@ -1,16 +1,59 @@
 package dto
 
 type EventTypeCreateRequest struct {
-	Name     string `json:"name" form:"name" validate:"required"`
-	Priority int    `json:"priority" form:"priority" validate:"required"`
+	Name            string `json:"name" form:"name" validate:"required"`
+	Priority        int    `json:"priority" form:"priority" validate:"required"`
+	SetupMinutes    int    `json:"setup_minutes" form:"setup_minutes" validate:"min=0"`
+	TeardownMinutes int    `json:"teardown_minutes" form:"teardown_minutes" validate:"min=0"`
+	AssignStrategy  string `json:"assign_strategy" form:"assign_strategy" validate:"omitempty,oneof=manual round_robin least_loaded"`
 }
 
 type EventTypeDeleteByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
 
+type EventTypeRestoreRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
 type EventTypeUpdateRequest struct {
-	ID       int     `param:"id" validate:"required"`
-	Name     *string `json:"name" form:"name"`
-	Priority *int    `json:"priority" form:"priority"`
+	ID              int     `param:"id" validate:"required"`
+	Name            *string `json:"name" form:"name"`
+	Priority        *int    `json:"priority" form:"priority"`
+	SetupMinutes    *int    `json:"setup_minutes" form:"setup_minutes" validate:"omitempty,min=0"`
+	TeardownMinutes *int    `json:"teardown_minutes" form:"teardown_minutes" validate:"omitempty,min=0"`
+	AssignStrategy  *string `json:"assign_strategy" form:"assign_strategy" validate:"omitempty,oneof=manual round_robin least_loaded"`
+	Version         *int    `json:"version" form:"version"`
+}
+
+type EventTypeFindApprovalStepRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+// EventTypeApprovalStepRequest mengganti seluruh rantai persetujuan event type, steps kosong berarti tanpa rantai
+type EventTypeApprovalStepRequest struct {
+	ID    int                `param:"id" validate:"required"`
+	Steps []ApprovalStepItem `json:"steps" form:"steps" validate:"dive"`
+}
+
+type ApprovalStepItem struct {
+	StepOrder int  `json:"step_order" form:"step_order" validate:"required,min=1"`
+	RoleId    *int `json:"role_id" form:"role_id"`
+	UserId    *int `json:"user_id" form:"user_id"`
+}
+
+type EventTypeFindChecklistRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+// EventTypeChecklistRequest mengganti seluruh template checklist event type, tasks kosong berarti tanpa checklist bawaan
+type EventTypeChecklistRequest struct {
+	ID    int             `param:"id" validate:"required"`
+	Tasks []ChecklistItem `json:"tasks" form:"tasks" validate:"dive"`
+}
+
+type ChecklistItem struct {
+	Title         string `json:"title" form:"title" validate:"required"`
+	IsRequired    bool   `json:"is_required" form:"is_required"`
+	DueDaysBefore *int   `json:"due_days_before" form:"due_days_before" validate:"omitempty,min=0"`
 }
@@ -15,6 +15,14 @@ type FileDeleteByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
 
+type FileFindTrashRequest struct {
+	RequestId int `param:"request_id" validate:"required"`
+}
+
+type FileRestoreRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
 type FileUpdateRequest struct {
 	ID   int     `param:"id" validate:"required"`
 	Name *string `json:"name" form:"name"`
@@ -0,0 +1,43 @@
+package dto
+
+type LocationCreateRequest struct {
+	Name       string `json:"name" form:"name" validate:"required"`
+	Building   string `json:"building" form:"building" validate:"required"`
+	Floor      string `json:"floor" form:"floor"`
+	Capacity   int    `json:"capacity" form:"capacity" validate:"min=0"`
+	Facilities string `json:"facilities" form:"facilities"`
+	OpenTime   string `json:"open_time" form:"open_time"`
+	CloseTime  string `json:"close_time" form:"close_time"`
+	IsActive   *bool  `json:"is_active" form:"is_active"`
+}
+
+type LocationFindByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type LocationUpdateRequest struct {
+	ID         int     `param:"id" validate:"required"`
+	Name       *string `json:"name" form:"name"`
+	Building   *string `json:"building" form:"building"`
+	Floor      *string `json:"floor" form:"floor"`
+	Capacity   *int    `json:"capacity" form:"capacity" validate:"omitempty,min=0"`
+	Facilities *string `json:"facilities" form:"facilities"`
+	OpenTime   *string `json:"open_time" form:"open_time"`
+	CloseTime  *string `json:"close_time" form:"close_time"`
+	IsActive   *bool   `json:"is_active" form:"is_active"`
+}
+
+type LocationDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type BlackoutDateCreateRequest struct {
+	LocationId *int   `json:"location_id" form:"location_id"`
+	DateStart  string `json:"date_start" form:"date_start" validate:"required"`
+	DateEnd    string `json:"date_end" form:"date_end" validate:"required"`
+	Reason     string `json:"reason" form:"reason" validate:"required"`
+}
+
+type BlackoutDateDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
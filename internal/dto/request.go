@@ -4,18 +4,45 @@ import "mime/multipart"
 
 type RequestCreateRequest struct {
 	EventName        string `json:"event_name" form:"event_name" validate:"required"`
-	EventLocation    string `json:"event_location" form:"event_location" validate:"required"`
+	LocationId       int    `json:"location_id" form:"location_id" validate:"required"`
 	EventDateStart   string `json:"event_date_start" form:"event_date_start" validate:"required"`
 	EventDateEnd     string `json:"event_date_end" form:"event_date_end" validate:"required"`
 	Description      string `json:"description" form:"description" validate:"required"`
 	EventTypeId      int    `json:"event_type_id" form:"event_type_id" validate:"required"`
 	CountParticipant int    `json:"count_participant" form:"count_participant" validate:"required"`
+	Recurrence       string `json:"recurrence" form:"recurrence"`
+	RecurrenceExdate string `json:"recurrence_exdate" form:"recurrence_exdate"`
 	Files            []*multipart.FileHeader
+	CopyFiles        []RequestCopyFile `json:"-" form:"-"`
+}
+
+// RequestCopyFile: berkas drive yang disalin ke request baru saat clone
+type RequestCopyFile struct {
+	File     string
+	FileName string
+}
+
+// RequestCreateDraftRequest: field selain nama, tanggal dan jenis event boleh dilengkapi belakangan
+type RequestCreateDraftRequest struct {
+	EventName        string `json:"event_name" form:"event_name" validate:"required"`
+	LocationId       *int   `json:"location_id" form:"location_id"`
+	EventDateStart   string `json:"event_date_start" form:"event_date_start" validate:"required"`
+	EventDateEnd     string `json:"event_date_end" form:"event_date_end" validate:"required"`
+	Description      string `json:"description" form:"description"`
+	EventTypeId      int    `json:"event_type_id" form:"event_type_id" validate:"required"`
+	CountParticipant int    `json:"count_participant" form:"count_participant"`
+	Files            []*multipart.FileHeader
+}
+
+type RequestSubmitRequest struct {
+	ID int `param:"id" validate:"required"`
 }
 
 type RequestFindRequest struct {
 	UseAhp          *string `query:"use_ahp"`
 	EventComplexity *string `query:"event_complexity"`
+	AhpProfileId    *int    `query:"ahp_profile_id"`
+	AhpMethod       *string `query:"ahp_method"`
 }
 
 type RequestFindByIDRequest struct {
@@ -25,19 +52,26 @@ type RequestFindByIDRequest struct {
 type RequestUpdateRequest struct {
 	ID               int     `param:"id" validate:"required"`
 	EventName        *string `json:"event_name" form:"event_name"`
-	EventLocation    *string `json:"event_location" form:"event_location"`
+	LocationId       *int    `json:"location_id" form:"location_id"`
 	EventDateStart   *string `json:"event_date_start" form:"event_date_start"`
 	EventDateEnd     *string `json:"event_date_end" form:"event_date_end"`
 	Description      *string `json:"description" form:"description"`
 	EventTypeId      *int    `json:"event_type_id" form:"event_type_id"`
 	CountParticipant *int    `json:"count_participant" form:"count_participant"`
 	StatusId         *int    `json:"status_id" form:"status_id"`
+	Note             *string `json:"note" form:"note"`
+	Scope            *string `json:"scope" form:"scope" validate:"omitempty,oneof=this following"`
+	Version          *int    `json:"version" form:"version"`
 }
 
 type RequestDeleteByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
 
+type RequestRestoreRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
 type RequestExportRequest struct {
 	Format   string `query:"format" validate:"required"`
 	UserId   *int   `query:"user_id"`
@@ -47,3 +81,98 @@ type RequestExportRequest struct {
 type RequestExportByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
+
+type RequestFindTransitionByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type RequestFindHistoryByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type RequestRejectRequest struct {
+	ID     int    `param:"id" validate:"required"`
+	Reason string `json:"reason" form:"reason" validate:"required"`
+}
+
+type RequestCancelRequest struct {
+	ID     int    `param:"id" validate:"required"`
+	Reason string `json:"reason" form:"reason" validate:"required"`
+}
+
+type RequestAvailabilityRequest struct {
+	LocationId     int    `query:"location_id" validate:"required"`
+	EventDateStart string `query:"event_date_start" validate:"required"`
+	EventDateEnd   string `query:"event_date_end" validate:"required"`
+	EventTypeId    *int   `query:"event_type_id"`
+	ExcludeId      *int   `query:"exclude_id"`
+}
+
+type RequestSeriesStatusRequest struct {
+	ID       int    `param:"id" validate:"required"`
+	StatusId int    `json:"status_id" form:"status_id" validate:"required"`
+	Note     string `json:"note" form:"note"`
+}
+
+// RequestBulkRequest: action status butuh status_id, action assign butuh assignee_id, action delete cukup ids
+type RequestBulkRequest struct {
+	Action     string `json:"action" form:"action" validate:"required,oneof=status delete assign"`
+	Ids        []int  `json:"ids" form:"ids" validate:"required,min=1"`
+	StatusId   int    `json:"status_id" form:"status_id"`
+	AssigneeId int    `json:"assignee_id" form:"assignee_id"`
+	Note       string `json:"note" form:"note"`
+}
+
+// RequestAssignRequest: assignee_id kosong berarti dipilih otomatis sesuai strategi event type
+type RequestAssignRequest struct {
+	ID         int  `param:"id" validate:"required"`
+	AssigneeId *int `json:"assignee_id" form:"assignee_id"`
+}
+
+type RequestFindApprovalRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type RequestApprovalDecisionRequest struct {
+	ID       int    `param:"id" validate:"required"`
+	Decision string `json:"decision" form:"decision" validate:"required,oneof=approve reject"`
+	Comment  string `json:"comment" form:"comment"`
+}
+
+type RequestFindSeriesByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type RequestCloneRequest struct {
+	ID               int     `param:"id" validate:"required"`
+	EventName        *string `json:"event_name" form:"event_name"`
+	LocationId       *int    `json:"location_id" form:"location_id"`
+	EventDateStart   string  `json:"event_date_start" form:"event_date_start" validate:"required"`
+	EventDateEnd     string  `json:"event_date_end" form:"event_date_end" validate:"required"`
+	CountParticipant *int    `json:"count_participant" form:"count_participant"`
+	CopyFiles        bool    `json:"copy_files" form:"copy_files"`
+}
+
+type RequestApplyTemplateRequest struct {
+	ID               int     `param:"id" validate:"required"`
+	EventName        *string `json:"event_name" form:"event_name"`
+	LocationId       *int    `json:"location_id" form:"location_id"`
+	EventDateStart   string  `json:"event_date_start" form:"event_date_start" validate:"required"`
+	EventDateEnd     string  `json:"event_date_end" form:"event_date_end" validate:"required"`
+	CountParticipant *int    `json:"count_participant" form:"count_participant"`
+}
+
+type RequestFindAssetRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+// RequestAssetRequest mengganti seluruh pesanan aset request, assets kosong berarti tanpa aset
+type RequestAssetRequest struct {
+	ID     int                `param:"id" validate:"required"`
+	Assets []RequestAssetItem `json:"assets" form:"assets" validate:"dive"`
+}
+
+type RequestAssetItem struct {
+	AssetId  int `json:"asset_id" form:"asset_id" validate:"required"`
+	Quantity int `json:"quantity" form:"quantity" validate:"required,min=1"`
+}
@@ -0,0 +1,25 @@
+package dto
+
+type RequestBudgetCreateRequest struct {
+	RequestId       int    `json:"request_id" form:"request_id" validate:"required"`
+	Category        string `json:"category" form:"category" validate:"required"`
+	Description     string `json:"description" form:"description"`
+	EstimatedAmount int64  `json:"estimated_amount" form:"estimated_amount" validate:"min=0"`
+	ActualAmount    *int64 `json:"actual_amount" form:"actual_amount" validate:"omitempty,min=0"`
+}
+
+type RequestBudgetFindByRequestIDRequest struct {
+	RequestId int `param:"request_id" validate:"required"`
+}
+
+type RequestBudgetDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type RequestBudgetUpdateRequest struct {
+	ID              int     `param:"id" validate:"required"`
+	Category        *string `json:"category" form:"category"`
+	Description     *string `json:"description" form:"description"`
+	EstimatedAmount *int64  `json:"estimated_amount" form:"estimated_amount" validate:"omitempty,min=0"`
+	ActualAmount    *int64  `json:"actual_amount" form:"actual_amount" validate:"omitempty,min=0"`
+}
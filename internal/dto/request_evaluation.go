@@ -0,0 +1,25 @@
+package dto
+
+type RequestEvaluationCreateRequest struct {
+	RequestId        int    `json:"request_id" form:"request_id" validate:"required"`
+	RatingLocation   int    `json:"rating_location" form:"rating_location" validate:"required,min=1,max=5"`
+	RatingFacility   int    `json:"rating_facility" form:"rating_facility" validate:"required,min=1,max=5"`
+	RatingService    int    `json:"rating_service" form:"rating_service" validate:"required,min=1,max=5"`
+	RatingOverall    int    `json:"rating_overall" form:"rating_overall" validate:"required,min=1,max=5"`
+	Comment          string `json:"comment" form:"comment"`
+	ActualAttendance *int   `json:"actual_attendance" form:"actual_attendance" validate:"omitempty,min=0"`
+}
+
+type RequestEvaluationFindByRequestIDRequest struct {
+	RequestId int `param:"request_id" validate:"required"`
+}
+
+type RequestEvaluationUpdateRequest struct {
+	ID               int     `param:"id" validate:"required"`
+	RatingLocation   *int    `json:"rating_location" form:"rating_location" validate:"omitempty,min=1,max=5"`
+	RatingFacility   *int    `json:"rating_facility" form:"rating_facility" validate:"omitempty,min=1,max=5"`
+	RatingService    *int    `json:"rating_service" form:"rating_service" validate:"omitempty,min=1,max=5"`
+	RatingOverall    *int    `json:"rating_overall" form:"rating_overall" validate:"omitempty,min=1,max=5"`
+	Comment          *string `json:"comment" form:"comment"`
+	ActualAttendance *int    `json:"actual_attendance" form:"actual_attendance" validate:"omitempty,min=0"`
+}
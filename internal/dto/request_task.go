@@ -0,0 +1,26 @@
+package dto
+
+type RequestTaskCreateRequest struct {
+	RequestId  int     `json:"request_id" form:"request_id" validate:"required"`
+	Title      string  `json:"title" form:"title" validate:"required"`
+	AssigneeId *int    `json:"assignee_id" form:"assignee_id"`
+	DueDate    *string `json:"due_date" form:"due_date"`
+	IsRequired bool    `json:"is_required" form:"is_required"`
+}
+
+type RequestTaskFindByRequestIDRequest struct {
+	RequestId int `param:"request_id" validate:"required"`
+}
+
+type RequestTaskDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
+type RequestTaskUpdateRequest struct {
+	ID         int     `param:"id" validate:"required"`
+	Title      *string `json:"title" form:"title"`
+	AssigneeId *int    `json:"assignee_id" form:"assignee_id"`
+	DueDate    *string `json:"due_date" form:"due_date"`
+	IsRequired *bool   `json:"is_required" form:"is_required"`
+	IsDone     *bool   `json:"is_done" form:"is_done"`
+}
@@ -0,0 +1,10 @@
+package dto
+
+type RequestTemplateCreateRequest struct {
+	RequestId int    `json:"request_id" form:"request_id" validate:"required"`
+	Name      string `json:"name" form:"name" validate:"required"`
+}
+
+type RequestTemplateDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
@@ -0,0 +1,16 @@
+package dto
+
+type SlaCreateRequest struct {
+	StatusId      int  `json:"status_id" form:"status_id" validate:"required"`
+	EventTypeId   *int `json:"event_type_id" form:"event_type_id"`
+	DurationHours int  `json:"duration_hours" form:"duration_hours" validate:"required,min=1"`
+}
+
+type SlaUpdateRequest struct {
+	ID            int  `param:"id" validate:"required"`
+	DurationHours *int `json:"duration_hours" form:"duration_hours" validate:"omitempty,min=1"`
+}
+
+type SlaDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
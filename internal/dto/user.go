@@ -11,16 +11,21 @@ type UserFindByIDRequest struct {
 }
 
 type UserUpdateRequest struct {
-	ID     int     `param:"id" validate:"required"`
-	Name   *string `json:"name" form:"name"`
-	Email  *string `json:"email" form:"email"`
-	RoleId *int    `json:"role_id" form:"role_id"`
+	ID      int     `param:"id" validate:"required"`
+	Name    *string `json:"name" form:"name"`
+	Email   *string `json:"email" form:"email"`
+	RoleId  *int    `json:"role_id" form:"role_id"`
+	Version *int    `json:"version" form:"version"`
 }
 
 type UserDeleteByIDRequest struct {
 	ID int `param:"id" validate:"required"`
 }
 
+type UserRestoreRequest struct {
+	ID int `param:"id" validate:"required"`
+}
+
 type UserChangePasswordRequest struct {
 	ID          int    `param:"id" validate:"required"`
 	OldPassword string `json:"old_password" form:"old_password" validate:"required"`
@@ -30,3 +35,14 @@ type UserChangePasswordRequest struct {
 type UserExportRequest struct {
 	Format string `query:"format" validate:"required"`
 }
+
+type UserDelegationCreateRequest struct {
+	DelegateId int    `json:"delegate_id" form:"delegate_id" validate:"required"`
+	DateStart  string `json:"date_start" form:"date_start" validate:"required"`
+	DateEnd    string `json:"date_end" form:"date_end" validate:"required"`
+	Reason     string `json:"reason" form:"reason"`
+}
+
+type UserDelegationDeleteByIDRequest struct {
+	ID int `param:"id" validate:"required"`
+}
@@ -23,16 +23,33 @@ type Factory struct {
 }
 
 type Repository_initiated struct {
-	UserRepository         repository.User
-	RoleRepository         repository.Role
-	StatusRepository       repository.Status
-	NotificationRepository repository.Notification
-	RequestRepository      repository.Request
-	EventTypeRepository    repository.EventType
-	FileRepository         repository.File
-	CommentRepository      repository.Comment
-	AhpHistoryRepository   repository.AhpHistory
-	DashboardRepository    repository.Dashboard
+	UserRepository                 repository.User
+	RoleRepository                 repository.Role
+	StatusRepository               repository.Status
+	NotificationRepository         repository.Notification
+	RequestRepository              repository.Request
+	EventTypeRepository            repository.EventType
+	FileRepository                 repository.File
+	CommentRepository              repository.Comment
+	AhpHistoryRepository           repository.AhpHistory
+	DashboardRepository            repository.Dashboard
+	RequestStatusHistoryRepository repository.RequestStatusHistory
+	LocationRepository             repository.Location
+	BlackoutDateRepository         repository.BlackoutDate
+	RequestSeriesRepository        repository.RequestSeries
+	RequestTemplateRepository      repository.RequestTemplate
+	SlaRepository                  repository.Sla
+	ApprovalStepRepository         repository.ApprovalStep
+	RequestApprovalRepository      repository.RequestApproval
+	DelegationRepository           repository.Delegation
+	ChecklistTemplateRepository    repository.ChecklistTemplate
+	RequestTaskRepository          repository.RequestTask
+	AssetRepository                repository.Asset
+	RequestAssetRepository         repository.RequestAsset
+	RequestBudgetRepository        repository.RequestBudget
+	RequestEvaluationRepository    repository.RequestEvaluation
+	TrashRepository                repository.Trash
+	AhpCriteriaProfileRepository   repository.AhpCriteriaProfile
 }
 
 type GoogleDrive struct {
@@ -99,4 +116,21 @@ func (f *Factory) SetupRepository() {
 	f.CommentRepository = repository.NewComment(f.Db)
 	f.AhpHistoryRepository = repository.NewAhpHistory(f.Db)
 	f.DashboardRepository = repository.NewDashboard(f.Db)
+	f.RequestStatusHistoryRepository = repository.NewRequestStatusHistory(f.Db)
+	f.LocationRepository = repository.NewLocation(f.Db)
+	f.BlackoutDateRepository = repository.NewBlackoutDate(f.Db)
+	f.RequestSeriesRepository = repository.NewRequestSeries(f.Db)
+	f.RequestTemplateRepository = repository.NewRequestTemplate(f.Db)
+	f.SlaRepository = repository.NewSla(f.Db)
+	f.ApprovalStepRepository = repository.NewApprovalStep(f.Db)
+	f.RequestApprovalRepository = repository.NewRequestApproval(f.Db)
+	f.DelegationRepository = repository.NewDelegation(f.Db)
+	f.ChecklistTemplateRepository = repository.NewChecklistTemplate(f.Db)
+	f.RequestTaskRepository = repository.NewRequestTask(f.Db)
+	f.AssetRepository = repository.NewAsset(f.Db)
+	f.RequestAssetRepository = repository.NewRequestAsset(f.Db)
+	f.RequestBudgetRepository = repository.NewRequestBudget(f.Db)
+	f.RequestEvaluationRepository = repository.NewRequestEvaluation(f.Db)
+	f.TrashRepository = repository.NewTrash(f.Db)
+	f.AhpCriteriaProfileRepository = repository.NewAhpCriteriaProfile(f.Db)
 }
@@ -4,12 +4,16 @@ import (
 	"fmt"
 	"net/http"
 
+	ahpcriteria "bm_binus/internal/app/ahp_criteria"
 	ahphistory "bm_binus/internal/app/ahp_history"
+	"bm_binus/internal/app/asset"
 	"bm_binus/internal/app/auth"
 	"bm_binus/internal/app/dashboard"
+	"bm_binus/internal/app/location"
 	"bm_binus/internal/app/notification"
 	"bm_binus/internal/app/request"
 	"bm_binus/internal/app/role"
+	"bm_binus/internal/app/sla"
 	"bm_binus/internal/app/status"
 	user "bm_binus/internal/app/user"
 	"bm_binus/internal/config"
@@ -40,5 +44,9 @@ func Init(e *echo.Echo, f *factory.Factory) {
 	notification.NewHandler(f).Route(e.Group("/notification"))
 	request.NewHandler(f).Route(e.Group("/request"))
 	ahphistory.NewHandler(f).Route(e.Group("/ahp-history"))
+	ahpcriteria.NewHandler(f).Route(e.Group("/ahp-criteria"))
 	dashboard.NewHandler(f).Route(e.Group("/dashboard"))
+	location.NewHandler(f).Route(e.Group("/location"))
+	sla.NewHandler(f).Route(e.Group("/sla"))
+	asset.NewHandler(f).Route(e.Group("/asset"))
 }
@@ -0,0 +1,85 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+
+	"gorm.io/gorm"
+)
+
+// AhpCriteriaProfileEntity: profil bobot kriteria AHP milik seorang BM, isi perbandingannya disimpan per versi
+type AhpCriteriaProfileEntity struct {
+	UserId        int    `json:"user_id"`
+	Name          string `json:"name"`
+	LatestVersion int    `json:"latest_version"`
+	IsActive      bool   `json:"is_active"`
+	IsDelete      bool   `json:"is_delete"`
+}
+
+// AhpCriteriaProfileEntityModel ...
+type AhpCriteriaProfileEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	AhpCriteriaProfileEntity
+
+	abstraction.Entity
+
+	User UserEntityModel `json:"user" gorm:"foreignKey:UserId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (AhpCriteriaProfileEntityModel) TableName() string {
+	return "ahp_criteria_profile"
+}
+
+type AhpCriteriaProfileCountDataModel struct {
+	Count int `json:"count"`
+}
+
+func (m *AhpCriteriaProfileEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	// m.UpdatedAt = general.NowLocal()
+	return
+}
+
+func (m *AhpCriteriaProfileEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	// m.CreatedAt = *general.Now()
+	return
+}
+
+// AhpCriteriaProfileVersionEntity: satu versi perbandingan kriteria, comparison/matrix/weights berupa JSON
+type AhpCriteriaProfileVersionEntity struct {
+	ProfileId        int     `json:"profile_id"`
+	Version          int     `json:"version"`
+	Comparison       string  `json:"comparison"`
+	Matrix           string  `json:"matrix"`
+	Weights          string  `json:"weights"`
+	ConsistencyRatio float64 `json:"consistency_ratio"`
+}
+
+// AhpCriteriaProfileVersionEntityModel ...
+type AhpCriteriaProfileVersionEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	AhpCriteriaProfileVersionEntity
+
+	abstraction.EntityWithBy
+
+	CreateBy UserEntityModel `json:"create_by" gorm:"foreignKey:CreatedBy"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (AhpCriteriaProfileVersionEntityModel) TableName() string {
+	return "ahp_criteria_profile_version"
+}
+
+func (m *AhpCriteriaProfileVersionEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedBy = m.Context.Auth.ID
+	return
+}
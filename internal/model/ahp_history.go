@@ -13,6 +13,7 @@ type AhpHistoryEntity struct {
 	AlternatifComparison string `json:"alternatif_comparison"`
 	PriorityGlobal       string `json:"priority_global"`
 	ReferenceRequest     int    `json:"reference_request"`
+	Method               string `json:"method"`
 	IsDelete             bool   `json:"is_delete"`
 }
 
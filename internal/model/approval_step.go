@@ -0,0 +1,36 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+)
+
+// ApprovalStepEntity: satu approver dalam rantai persetujuan event type, diisi role_id atau user_id.
+// step dengan step_order yang sama berjalan paralel, step_order berikutnya menunggu urutan sebelumnya selesai
+type ApprovalStepEntity struct {
+	EventTypeId int  `json:"event_type_id"`
+	StepOrder   int  `json:"step_order"`
+	RoleId      *int `json:"role_id"`
+	UserId      *int `json:"user_id"`
+	IsDelete    bool `json:"is_delete"`
+}
+
+// ApprovalStepEntityModel ...
+type ApprovalStepEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	ApprovalStepEntity
+
+	abstraction.Entity
+
+	Role *RoleEntityModel `json:"role" gorm:"foreignKey:RoleId"`
+	User *UserEntityModel `json:"user" gorm:"foreignKey:UserId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (ApprovalStepEntityModel) TableName() string {
+	return "approval_step"
+}
@@ -0,0 +1,43 @@
+package model
+
+import "bm_binus/internal/abstraction"
+
+// AssetEntity: inventaris peralatan yang bisa dipinjam untuk event, quantity adalah total stok
+type AssetEntity struct {
+	Name       string `json:"name"`
+	ItemType   string `json:"item_type"`
+	Quantity   int    `json:"quantity"`
+	LocationId *int   `json:"location_id"`
+	IsActive   bool   `json:"is_active"`
+	IsDelete   bool   `json:"is_delete"`
+}
+
+// AssetEntityModel ...
+type AssetEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	AssetEntity
+
+	abstraction.Entity
+
+	Location *LocationEntityModel `json:"location" gorm:"foreignKey:LocationId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (AssetEntityModel) TableName() string {
+	return "asset"
+}
+
+type AssetCountDataModel struct {
+	Count int `json:"count"`
+}
+
+// AssetReserved: jumlah aset yang sudah dipesan request lain pada rentang waktu tertentu
+type AssetReserved struct {
+	AssetID int `json:"asset_id"`
+	Total   int `json:"total"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+	"time"
+)
+
+type BlackoutDateEntity struct {
+	LocationId *int      `json:"location_id"`
+	DateStart  time.Time `json:"date_start"`
+	DateEnd    time.Time `json:"date_end"`
+	Reason     string    `json:"reason"`
+	IsDelete   bool      `json:"is_delete"`
+}
+
+// BlackoutDateEntityModel ...
+type BlackoutDateEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	BlackoutDateEntity
+
+	abstraction.Entity
+
+	Location *LocationEntityModel `json:"location" gorm:"foreignKey:LocationId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (BlackoutDateEntityModel) TableName() string {
+	return "blackout_date"
+}
+
+type BlackoutDateCountDataModel struct {
+	Count int `json:"count"`
+}
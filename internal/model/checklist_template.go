@@ -0,0 +1,33 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+)
+
+// ChecklistTemplateEntity: tugas bawaan event type yang disalin ke request saat masuk proses.
+// due_days_before dihitung mundur dari tanggal mulai event, nil berarti tanpa tenggat
+type ChecklistTemplateEntity struct {
+	EventTypeId   int    `json:"event_type_id"`
+	Title         string `json:"title"`
+	IsRequired    bool   `json:"is_required"`
+	DueDaysBefore *int   `json:"due_days_before"`
+	IsDelete      bool   `json:"is_delete"`
+}
+
+// ChecklistTemplateEntityModel ...
+type ChecklistTemplateEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	ChecklistTemplateEntity
+
+	abstraction.Entity
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (ChecklistTemplateEntityModel) TableName() string {
+	return "checklist_template"
+}
@@ -2,14 +2,16 @@ package model
 
 import (
 	"bm_binus/internal/abstraction"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type CommentEntity struct {
-	RequestId int    `json:"request_id"`
-	Comment   string `json:"comment"`
-	IsDelete  bool   `json:"is_delete"`
+	RequestId int        `json:"request_id"`
+	Comment   string     `json:"comment"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	IsDelete  bool       `json:"is_delete"`
 }
 
 // CommentEntityModel ...
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+	"time"
+)
+
+// DelegationEntity: periode user (mis. BM yang cuti) mengalihkan notifikasi, penugasan dan hak persetujuan ke delegasinya
+type DelegationEntity struct {
+	UserId     int       `json:"user_id"`
+	DelegateId int       `json:"delegate_id"`
+	DateStart  time.Time `json:"date_start"`
+	DateEnd    time.Time `json:"date_end"`
+	Reason     string    `json:"reason"`
+	IsDelete   bool      `json:"is_delete"`
+}
+
+// DelegationEntityModel ...
+type DelegationEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	DelegationEntity
+
+	abstraction.Entity
+
+	User     UserEntityModel `json:"user" gorm:"foreignKey:UserId"`
+	Delegate UserEntityModel `json:"delegate" gorm:"foreignKey:DelegateId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (DelegationEntityModel) TableName() string {
+	return "delegation"
+}
+
+type DelegationCountDataModel struct {
+	Count int `json:"count"`
+}
@@ -1,11 +1,19 @@
 package model
 
-import "bm_binus/internal/abstraction"
+import (
+	"bm_binus/internal/abstraction"
+	"time"
+)
 
 type EventTypeEntity struct {
-	Name     string `json:"name"`
-	Priority int    `json:"priority"`
-	IsDelete bool   `json:"is_delete"`
+	Name            string     `json:"name"`
+	Priority        int        `json:"priority"`
+	SetupMinutes    int        `json:"setup_minutes"`
+	TeardownMinutes int        `json:"teardown_minutes"`
+	AssignStrategy  string     `json:"assign_strategy"`
+	Version         int        `json:"version"`
+	DeletedAt       *time.Time `json:"deleted_at"`
+	IsDelete        bool       `json:"is_delete"`
 }
 
 // EventTypeEntityModel ...
@@ -2,15 +2,17 @@ package model
 
 import (
 	"bm_binus/internal/abstraction"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type FileEntity struct {
-	RequestId int    `json:"request_id"`
-	File      string `json:"file"`
-	FileName  string `json:"file_name"`
-	IsDelete  bool   `json:"is_delete"`
+	RequestId int        `json:"request_id"`
+	File      string     `json:"file"`
+	FileName  string     `json:"file_name"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	IsDelete  bool       `json:"is_delete"`
 }
 
 // FileEntityModel ...
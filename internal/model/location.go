@@ -0,0 +1,37 @@
+package model
+
+import "bm_binus/internal/abstraction"
+
+type LocationEntity struct {
+	Name       string `json:"name"`
+	Building   string `json:"building"`
+	Floor      string `json:"floor"`
+	Capacity   int    `json:"capacity"`
+	Facilities string `json:"facilities"`
+	OpenTime   string `json:"open_time"`
+	CloseTime  string `json:"close_time"`
+	IsActive   bool   `json:"is_active"`
+	IsDelete   bool   `json:"is_delete"`
+}
+
+// LocationEntityModel ...
+type LocationEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	LocationEntity
+
+	abstraction.Entity
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (LocationEntityModel) TableName() string {
+	return "location"
+}
+
+type LocationCountDataModel struct {
+	Count int `json:"count"`
+}
@@ -8,16 +8,26 @@ import (
 )
 
 type RequestEntity struct {
-	UserId           int       `json:"user_id"`
-	EventName        string    `json:"event_name"`
-	EventLocation    string    `json:"event_location"`
-	EventDateStart   time.Time `json:"event_date_start"`
-	EventDateEnd     time.Time `json:"event_date_end"`
-	Description      string    `json:"description"`
-	EventTypeId      int       `json:"event_type_id"`
-	CountParticipant int       `json:"count_participant"`
-	StatusId         int       `json:"status_id"`
-	IsDelete         bool      `json:"is_delete"`
+	UserId           int        `json:"user_id"`
+	EventName        string     `json:"event_name"`
+	EventLocation    string     `json:"event_location"`
+	LocationId       *int       `json:"location_id"`
+	EventDateStart   time.Time  `json:"event_date_start"`
+	EventDateEnd     time.Time  `json:"event_date_end"`
+	Description      string     `json:"description"`
+	EventTypeId      int        `json:"event_type_id"`
+	CountParticipant int        `json:"count_participant"`
+	StatusId         int        `json:"status_id"`
+	StatusReason     string     `json:"status_reason"`
+	SeriesId         *int       `json:"series_id"`
+	SeriesIndex      int        `json:"series_index"`
+	AssigneeId       *int       `json:"assignee_id"`
+	StatusChangedAt  *time.Time `json:"status_changed_at"`
+	IsOverdue        bool       `json:"is_overdue"`
+	EscalatedAt      *time.Time `json:"escalated_at"`
+	Version          int        `json:"version"`
+	DeletedAt        *time.Time `json:"deleted_at"`
+	IsDelete         bool       `json:"is_delete"`
 }
 
 // RequestEntityModel ...
@@ -32,6 +42,8 @@ type RequestEntityModel struct {
 	User      UserEntityModel      `json:"user" gorm:"foreignKey:UserId"`
 	EventType EventTypeEntityModel `json:"event_type" gorm:"foreignKey:EventTypeId"`
 	Status    StatusEntityModel    `json:"status" gorm:"foreignKey:StatusId"`
+	Location  *LocationEntityModel `json:"location" gorm:"foreignKey:LocationId"`
+	Assignee  *UserEntityModel     `json:"assignee" gorm:"foreignKey:AssigneeId"`
 
 	// context
 	Context *abstraction.Context `json:"-" gorm:"-"`
@@ -46,6 +58,14 @@ type RequestCountDataModel struct {
 	Count int `json:"count"`
 }
 
+// HandlerUsers: notifikasi untuk BM cukup dikirim ke assignee jika request sudah ditugaskan
+func (m *RequestEntityModel) HandlerUsers(userBM []*UserEntityModel) []*UserEntityModel {
+	if m.Assignee != nil {
+		return []*UserEntityModel{m.Assignee}
+	}
+	return userBM
+}
+
 func (m *RequestEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
 	// m.UpdatedAt = general.NowLocal()
 	return
@@ -65,3 +85,18 @@ type RequestCountByEventType struct {
 	EventTypeID int `json:"event_type_id"`
 	Total       int `json:"total"`
 }
+
+// RequestRankInputModel: kolom request yang menjadi input ranking AHP
+type RequestRankInputModel struct {
+	ID                int       `json:"id"`
+	EventName         string    `json:"event_name"`
+	EventDateStart    time.Time `json:"event_date_start"`
+	CountParticipant  int       `json:"count_participant"`
+	EventTypePriority int       `json:"event_type_priority"`
+	CreatedAt         time.Time `json:"created_at"`
+}
+
+type RequestCountByAssignee struct {
+	AssigneeID int `json:"assignee_id"`
+	Total      int `json:"total"`
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+	"time"
+)
+
+// RequestApprovalEntity: salinan approval step saat request masuk validasi beserta keputusan approver
+type RequestApprovalEntity struct {
+	RequestId  int        `json:"request_id"`
+	StepOrder  int        `json:"step_order"`
+	RoleId     *int       `json:"role_id"`
+	UserId     *int       `json:"user_id"`
+	Decision   string     `json:"decision"`
+	Comment    string     `json:"comment"`
+	DecidedBy  *int       `json:"decided_by"`
+	OnBehalfOf *int       `json:"on_behalf_of"`
+	DecidedAt  *time.Time `json:"decided_at"`
+	IsDelete   bool       `json:"is_delete"`
+}
+
+// RequestApprovalEntityModel ...
+type RequestApprovalEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestApprovalEntity
+
+	abstraction.Entity
+
+	Role          *RoleEntityModel `json:"role" gorm:"foreignKey:RoleId"`
+	User          *UserEntityModel `json:"user" gorm:"foreignKey:UserId"`
+	DecidedByUser *UserEntityModel `json:"decided_by_user" gorm:"foreignKey:DecidedBy"`
+	OnBehalf      *UserEntityModel `json:"on_behalf" gorm:"foreignKey:OnBehalfOf"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestApprovalEntityModel) TableName() string {
+	return "request_approval"
+}
@@ -0,0 +1,31 @@
+package model
+
+import "bm_binus/internal/abstraction"
+
+// RequestAssetEntity: jumlah aset yang dipesan sebuah request untuk rentang tanggal eventnya
+type RequestAssetEntity struct {
+	RequestId int  `json:"request_id"`
+	AssetId   int  `json:"asset_id"`
+	Quantity  int  `json:"quantity"`
+	IsDelete  bool `json:"is_delete"`
+}
+
+// RequestAssetEntityModel ...
+type RequestAssetEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestAssetEntity
+
+	abstraction.Entity
+
+	Asset AssetEntityModel `json:"asset" gorm:"foreignKey:AssetId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestAssetEntityModel) TableName() string {
+	return "request_asset"
+}
@@ -0,0 +1,66 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+
+	"gorm.io/gorm"
+)
+
+// RequestBudgetEntity: satu pos anggaran event, actual_amount diisi setelah biaya benar-benar keluar (nominal rupiah)
+type RequestBudgetEntity struct {
+	RequestId       int    `json:"request_id"`
+	Category        string `json:"category"`
+	Description     string `json:"description"`
+	EstimatedAmount int64  `json:"estimated_amount"`
+	ActualAmount    *int64 `json:"actual_amount"`
+	IsDelete        bool   `json:"is_delete"`
+}
+
+// RequestBudgetEntityModel ...
+type RequestBudgetEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestBudgetEntity
+
+	abstraction.EntityWithBy
+
+	CreateBy UserEntityModel `json:"create_by" gorm:"foreignKey:CreatedBy"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestBudgetEntityModel) TableName() string {
+	return "request_budget"
+}
+
+func (m *RequestBudgetEntityModel) BeforeUpdate(tx *gorm.DB) (err error) {
+	m.UpdatedBy = &m.Context.Auth.ID
+	return
+}
+
+func (m *RequestBudgetEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedBy = m.Context.Auth.ID
+	return
+}
+
+// RequestBudgetTotal: total estimasi dan realisasi biaya per request
+type RequestBudgetTotal struct {
+	RequestID int   `json:"request_id"`
+	Estimated int64 `json:"estimated"`
+	Actual    int64 `json:"actual"`
+}
+
+type RequestBudgetByEventType struct {
+	EventTypeID int   `json:"event_type_id"`
+	Estimated   int64 `json:"estimated"`
+	Actual      int64 `json:"actual"`
+}
+
+type RequestBudgetByMonth struct {
+	Month     string `json:"month"`
+	Estimated int64  `json:"estimated"`
+	Actual    int64  `json:"actual"`
+}
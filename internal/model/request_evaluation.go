@@ -0,0 +1,48 @@
+package model
+
+import "bm_binus/internal/abstraction"
+
+// RequestEvaluationEntity: evaluasi pasca event dari pemilik atau peserta, rating 1-5 per aspek.
+// actual_attendance hanya diisi pemilik request
+type RequestEvaluationEntity struct {
+	RequestId        int    `json:"request_id"`
+	UserId           int    `json:"user_id"`
+	RatingLocation   int    `json:"rating_location"`
+	RatingFacility   int    `json:"rating_facility"`
+	RatingService    int    `json:"rating_service"`
+	RatingOverall    int    `json:"rating_overall"`
+	Comment          string `json:"comment"`
+	ActualAttendance *int   `json:"actual_attendance"`
+	IsDelete         bool   `json:"is_delete"`
+}
+
+// RequestEvaluationEntityModel ...
+type RequestEvaluationEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestEvaluationEntity
+
+	abstraction.Entity
+
+	User UserEntityModel `json:"user" gorm:"foreignKey:UserId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestEvaluationEntityModel) TableName() string {
+	return "request_evaluation"
+}
+
+// RequestEvaluationSummary: rata-rata evaluasi, group_id berisi id request, lokasi atau event type sesuai pengelompokan
+type RequestEvaluationSummary struct {
+	GroupID          int     `json:"group_id"`
+	Count            int     `json:"count"`
+	RatingLocation   float64 `json:"rating_location"`
+	RatingFacility   float64 `json:"rating_facility"`
+	RatingService    float64 `json:"rating_service"`
+	RatingOverall    float64 `json:"rating_overall"`
+	ActualAttendance float64 `json:"actual_attendance"`
+}
@@ -0,0 +1,37 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+
+	"gorm.io/gorm"
+)
+
+type RequestSeriesEntity struct {
+	UserId         int    `json:"user_id"`
+	RecurrenceRule string `json:"recurrence_rule"`
+	Exdates        string `json:"exdates"`
+	CreatedBy      int    `json:"created_by"`
+}
+
+// RequestSeriesEntityModel ...
+type RequestSeriesEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestSeriesEntity
+
+	abstraction.EntityJustCreated
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestSeriesEntityModel) TableName() string {
+	return "request_series"
+}
+
+func (m *RequestSeriesEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedBy = m.Context.Auth.ID
+	return
+}
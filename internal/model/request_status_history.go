@@ -0,0 +1,48 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+
+	"gorm.io/gorm"
+)
+
+type RequestStatusHistoryEntity struct {
+	RequestId    int    `json:"request_id"`
+	FromStatusId *int   `json:"from_status_id"`
+	ToStatusId   int    `json:"to_status_id"`
+	Note         string `json:"note"`
+	CreatedBy    int    `json:"created_by"`
+	OnBehalfOf   *int   `json:"on_behalf_of"`
+}
+
+// RequestStatusHistoryEntityModel ...
+type RequestStatusHistoryEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestStatusHistoryEntity
+
+	abstraction.EntityJustCreated
+
+	FromStatus StatusEntityModel `json:"from_status" gorm:"foreignKey:FromStatusId"`
+	ToStatus   StatusEntityModel `json:"to_status" gorm:"foreignKey:ToStatusId"`
+	CreateBy   UserEntityModel   `json:"create_by" gorm:"foreignKey:CreatedBy"`
+	OnBehalf   *UserEntityModel  `json:"on_behalf" gorm:"foreignKey:OnBehalfOf"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestStatusHistoryEntityModel) TableName() string {
+	return "request_status_history"
+}
+
+type RequestStatusHistoryCountDataModel struct {
+	Count int `json:"count"`
+}
+
+func (m *RequestStatusHistoryEntityModel) BeforeCreate(tx *gorm.DB) (err error) {
+	m.CreatedBy = m.Context.Auth.ID
+	return
+}
@@ -0,0 +1,44 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+	"time"
+)
+
+// RequestTaskEntity: tugas persiapan event pada fase proses dan finalisasi
+type RequestTaskEntity struct {
+	RequestId  int        `json:"request_id"`
+	Title      string     `json:"title"`
+	AssigneeId *int       `json:"assignee_id"`
+	DueDate    *time.Time `json:"due_date"`
+	IsRequired bool       `json:"is_required"`
+	IsDone     bool       `json:"is_done"`
+	DoneBy     *int       `json:"done_by"`
+	DoneAt     *time.Time `json:"done_at"`
+	IsDelete   bool       `json:"is_delete"`
+}
+
+// RequestTaskEntityModel ...
+type RequestTaskEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestTaskEntity
+
+	abstraction.Entity
+
+	Assignee   *UserEntityModel `json:"assignee" gorm:"foreignKey:AssigneeId"`
+	DoneByUser *UserEntityModel `json:"done_by_user" gorm:"foreignKey:DoneBy"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestTaskEntityModel) TableName() string {
+	return "request_task"
+}
+
+type RequestTaskCountDataModel struct {
+	Count int `json:"count"`
+}
@@ -0,0 +1,39 @@
+package model
+
+import "bm_binus/internal/abstraction"
+
+type RequestTemplateEntity struct {
+	UserId           int    `json:"user_id"`
+	Name             string `json:"name"`
+	EventName        string `json:"event_name"`
+	LocationId       *int   `json:"location_id"`
+	Description      string `json:"description"`
+	EventTypeId      int    `json:"event_type_id"`
+	CountParticipant int    `json:"count_participant"`
+	IsDelete         bool   `json:"is_delete"`
+}
+
+// RequestTemplateEntityModel ...
+type RequestTemplateEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	RequestTemplateEntity
+
+	abstraction.Entity
+
+	EventType EventTypeEntityModel `json:"event_type" gorm:"foreignKey:EventTypeId"`
+	Location  *LocationEntityModel `json:"location" gorm:"foreignKey:LocationId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (RequestTemplateEntityModel) TableName() string {
+	return "request_template"
+}
+
+type RequestTemplateCountDataModel struct {
+	Count int `json:"count"`
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"bm_binus/internal/abstraction"
+)
+
+// SlaEntity: batas waktu request berada di satu status, event_type_id kosong berarti berlaku untuk semua jenis event
+type SlaEntity struct {
+	StatusId      int  `json:"status_id"`
+	EventTypeId   *int `json:"event_type_id"`
+	DurationHours int  `json:"duration_hours"`
+	IsDelete      bool `json:"is_delete"`
+}
+
+// SlaEntityModel ...
+type SlaEntityModel struct {
+	ID int `json:"id" param:"id" form:"id" validate:"number,min=1" gorm:"primaryKey;autoIncrement;"`
+
+	// entity
+	SlaEntity
+
+	abstraction.Entity
+
+	Status    StatusEntityModel     `json:"status" gorm:"foreignKey:StatusId"`
+	EventType *EventTypeEntityModel `json:"event_type" gorm:"foreignKey:EventTypeId"`
+
+	// context
+	Context *abstraction.Context `json:"-" gorm:"-"`
+}
+
+// TableName ...
+func (SlaEntityModel) TableName() string {
+	return "sla"
+}
+
+type SlaCountDataModel struct {
+	Count int `json:"count"`
+}
@@ -2,16 +2,19 @@ package model
 
 import (
 	"bm_binus/internal/abstraction"
+	"time"
 
 	"gorm.io/gorm"
 )
 
 type UserEntity struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
-	RoleId   int    `json:"role_id"`
-	IsDelete bool   `json:"is_delete"`
+	Name      string     `json:"name"`
+	Email     string     `json:"email"`
+	Password  string     `json:"password"`
+	RoleId    int        `json:"role_id"`
+	Version   int        `json:"version"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	IsDelete  bool       `json:"is_delete"`
 }
 
 // UserEntityModel ...
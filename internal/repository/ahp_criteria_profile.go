@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type AhpCriteriaProfile interface {
+	Create(ctx *abstraction.Context, data *model.AhpCriteriaProfileEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.AhpCriteriaProfileEntityModel, error)
+	FindByUserId(ctx *abstraction.Context, user_id int) (data []*model.AhpCriteriaProfileEntityModel, err error)
+	FindActiveByUserId(ctx *abstraction.Context, user_id int) (*model.AhpCriteriaProfileEntityModel, error)
+	Update(ctx *abstraction.Context, data *model.AhpCriteriaProfileEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	DeactivateByUserId(ctx *abstraction.Context, user_id int) *gorm.DB
+	CreateVersion(ctx *abstraction.Context, data *model.AhpCriteriaProfileVersionEntityModel) *gorm.DB
+	FindVersion(ctx *abstraction.Context, profile_id int, version int) (*model.AhpCriteriaProfileVersionEntityModel, error)
+	FindVersions(ctx *abstraction.Context, profile_id int) (data []*model.AhpCriteriaProfileVersionEntityModel, err error)
+}
+
+type ahp_criteria_profile struct {
+	abstraction.Repository
+}
+
+func NewAhpCriteriaProfile(db *gorm.DB) *ahp_criteria_profile {
+	return &ahp_criteria_profile{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *ahp_criteria_profile) Create(ctx *abstraction.Context, data *model.AhpCriteriaProfileEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *ahp_criteria_profile) FindById(ctx *abstraction.Context, id int) (*model.AhpCriteriaProfileEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.AhpCriteriaProfileEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		Preload("User").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *ahp_criteria_profile) FindByUserId(ctx *abstraction.Context, user_id int) (data []*model.AhpCriteriaProfileEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("user_id = ? AND is_delete = ?", user_id, false).
+		Order("is_active DESC, created_at DESC").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *ahp_criteria_profile) FindActiveByUserId(ctx *abstraction.Context, user_id int) (*model.AhpCriteriaProfileEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.AhpCriteriaProfileEntityModel
+	err := conn.
+		Where("user_id = ? AND is_active = ? AND is_delete = ?", user_id, true, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *ahp_criteria_profile) Update(ctx *abstraction.Context, data *model.AhpCriteriaProfileEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+func (r *ahp_criteria_profile) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.AhpCriteriaProfileEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+// DeactivateByUserId: tiap BM hanya punya satu profil aktif untuk ranking
+func (r *ahp_criteria_profile) DeactivateByUserId(ctx *abstraction.Context, user_id int) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.AhpCriteriaProfileEntityModel{}).
+		Where("user_id = ? AND is_active = ?", user_id, true).
+		Update("is_active", false)
+}
+
+func (r *ahp_criteria_profile) CreateVersion(ctx *abstraction.Context, data *model.AhpCriteriaProfileVersionEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *ahp_criteria_profile) FindVersion(ctx *abstraction.Context, profile_id int, version int) (*model.AhpCriteriaProfileVersionEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.AhpCriteriaProfileVersionEntityModel
+	err := conn.
+		Where("profile_id = ? AND version = ?", profile_id, version).
+		Preload("CreateBy").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *ahp_criteria_profile) FindVersions(ctx *abstraction.Context, profile_id int) (data []*model.AhpCriteriaProfileVersionEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("profile_id = ?", profile_id).
+		Order("version DESC").
+		Preload("CreateBy").
+		Find(&data).
+		Error
+	return
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type ApprovalStep interface {
+	Create(ctx *abstraction.Context, data *model.ApprovalStepEntityModel) *gorm.DB
+	FindByEventTypeId(ctx *abstraction.Context, event_type_id int) (data []*model.ApprovalStepEntityModel, err error)
+	DeleteByEventTypeId(ctx *abstraction.Context, event_type_id int) *gorm.DB
+}
+
+type approval_step struct {
+	abstraction.Repository
+}
+
+func NewApprovalStep(db *gorm.DB) *approval_step {
+	return &approval_step{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *approval_step) Create(ctx *abstraction.Context, data *model.ApprovalStepEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *approval_step) FindByEventTypeId(ctx *abstraction.Context, event_type_id int) (data []*model.ApprovalStepEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("event_type_id = ? AND is_delete = ?", event_type_id, false).
+		Order("step_order ASC, id ASC").
+		Preload("Role").
+		Preload("User").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *approval_step) DeleteByEventTypeId(ctx *abstraction.Context, event_type_id int) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.ApprovalStepEntityModel{}).
+		Where("event_type_id = ? AND is_delete = ?", event_type_id, false).
+		Update("is_delete", true)
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+
+	"gorm.io/gorm"
+)
+
+type Asset interface {
+	Find(ctx *abstraction.Context, no_paging bool) (data []*model.AssetEntityModel, err error)
+	Count(ctx *abstraction.Context) (data *int, err error)
+	Create(ctx *abstraction.Context, data *model.AssetEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.AssetEntityModel, error)
+	FindByName(ctx *abstraction.Context, name string) (*model.AssetEntityModel, error)
+	Update(ctx *abstraction.Context, data *model.AssetEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+}
+
+type asset struct {
+	abstraction.Repository
+}
+
+func NewAsset(db *gorm.DB) *asset {
+	return &asset{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *asset) Find(ctx *abstraction.Context, no_paging bool) (data []*model.AssetEntityModel, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "asset", "is_delete = @false")
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	order := general.ProcessOrder(ctx)
+	err = r.CheckTrx(ctx).
+		Where(where, whereParam).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Preload("Location").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *asset) Count(ctx *abstraction.Context) (data *int, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "asset", "is_delete = @false")
+	var count model.AssetCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("asset").
+		Select("COUNT(*) AS count").
+		Where(where, whereParam).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *asset) Create(ctx *abstraction.Context, data *model.AssetEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *asset) FindById(ctx *abstraction.Context, id int) (*model.AssetEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.AssetEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		Preload("Location").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *asset) FindByName(ctx *abstraction.Context, name string) (*model.AssetEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.AssetEntityModel
+	err := conn.
+		Where("LOWER(TRIM(name)) = LOWER(TRIM(?)) AND is_delete = ?", name, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *asset) Update(ctx *abstraction.Context, data *model.AssetEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+func (r *asset) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.AssetEntityModel{}).Where("id = ?", id).Updates(data)
+}
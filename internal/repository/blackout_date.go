@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type BlackoutDate interface {
+	Find(ctx *abstraction.Context, no_paging bool) (data []*model.BlackoutDateEntityModel, err error)
+	Count(ctx *abstraction.Context) (data *int, err error)
+	Create(ctx *abstraction.Context, data *model.BlackoutDateEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.BlackoutDateEntityModel, error)
+	Update(ctx *abstraction.Context, data *model.BlackoutDateEntityModel) *gorm.DB
+	FindOverlap(ctx *abstraction.Context, location_id int, start time.Time, end time.Time) (data []*model.BlackoutDateEntityModel, err error)
+}
+
+type blackout_date struct {
+	abstraction.Repository
+}
+
+func NewBlackoutDate(db *gorm.DB) *blackout_date {
+	return &blackout_date{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *blackout_date) Find(ctx *abstraction.Context, no_paging bool) (data []*model.BlackoutDateEntityModel, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "blackout_date", "is_delete = @false")
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	order := general.ProcessOrder(ctx)
+	err = r.CheckTrx(ctx).
+		Where(where, whereParam).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Preload("Location").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *blackout_date) Count(ctx *abstraction.Context) (data *int, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "blackout_date", "is_delete = @false")
+	var count model.BlackoutDateCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("blackout_date").
+		Select("COUNT(*) AS count").
+		Where(where, whereParam).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *blackout_date) Create(ctx *abstraction.Context, data *model.BlackoutDateEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *blackout_date) FindById(ctx *abstraction.Context, id int) (*model.BlackoutDateEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.BlackoutDateEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *blackout_date) Update(ctx *abstraction.Context, data *model.BlackoutDateEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+// FindOverlap mengambil blackout yang berlaku untuk lokasi tersebut (atau semua lokasi) pada rentang start-end
+func (r *blackout_date) FindOverlap(ctx *abstraction.Context, location_id int, start time.Time, end time.Time) (data []*model.BlackoutDateEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ? AND (location_id IS NULL OR location_id = ?)", false, location_id).
+		Where("date_start < ? AND date_end > ?", end, start).
+		Order("date_start ASC").
+		Find(&data).
+		Error
+	return
+}
@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type ChecklistTemplate interface {
+	Create(ctx *abstraction.Context, data *model.ChecklistTemplateEntityModel) *gorm.DB
+	FindByEventTypeId(ctx *abstraction.Context, event_type_id int) (data []*model.ChecklistTemplateEntityModel, err error)
+	DeleteByEventTypeId(ctx *abstraction.Context, event_type_id int) *gorm.DB
+}
+
+type checklist_template struct {
+	abstraction.Repository
+}
+
+func NewChecklistTemplate(db *gorm.DB) *checklist_template {
+	return &checklist_template{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *checklist_template) Create(ctx *abstraction.Context, data *model.ChecklistTemplateEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *checklist_template) FindByEventTypeId(ctx *abstraction.Context, event_type_id int) (data []*model.ChecklistTemplateEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("event_type_id = ? AND is_delete = ?", event_type_id, false).
+		Order("id ASC").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *checklist_template) DeleteByEventTypeId(ctx *abstraction.Context, event_type_id int) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.ChecklistTemplateEntityModel{}).
+		Where("event_type_id = ? AND is_delete = ?", event_type_id, false).
+		Update("is_delete", true)
+}
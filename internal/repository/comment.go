@@ -15,6 +15,9 @@ type Comment interface {
 	Create(ctx *abstraction.Context, data *model.CommentEntityModel) *gorm.DB
 	FindById(ctx *abstraction.Context, id int) (*model.CommentEntityModel, error)
 	Update(ctx *abstraction.Context, data *model.CommentEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	FindTrashByRequestId(ctx *abstraction.Context, request_id int) (data []*model.CommentEntityModel, err error)
+	FindDeletedById(ctx *abstraction.Context, id int) (*model.CommentEntityModel, error)
 }
 
 type comment struct {
@@ -81,3 +84,31 @@ func (r *comment) FindById(ctx *abstraction.Context, id int) (*model.CommentEnti
 func (r *comment) Update(ctx *abstraction.Context, data *model.CommentEntityModel) *gorm.DB {
 	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
 }
+
+func (r *comment) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.CommentEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+func (r *comment) FindTrashByRequestId(ctx *abstraction.Context, request_id int) (data []*model.CommentEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, true).
+		Order("deleted_at DESC, id DESC").
+		Preload("CreateBy").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *comment) FindDeletedById(ctx *abstraction.Context, id int) (*model.CommentEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.CommentEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, true).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
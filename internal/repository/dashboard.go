@@ -3,6 +3,8 @@ package repository
 import (
 	"bm_binus/internal/abstraction"
 	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -10,6 +12,9 @@ import (
 type Dashboard interface {
 	GetByStatus(ctx *abstraction.Context, user_id *int) (data []*model.RequestCountByStatus, err error)
 	GetByEventType(ctx *abstraction.Context, user_id *int) (data []*model.RequestCountByEventType, err error)
+	CountOverdue(ctx *abstraction.Context, user_id *int) (data int, err error)
+	GetBudgetByEventType(ctx *abstraction.Context, user_id *int) (data []*model.RequestBudgetByEventType, err error)
+	GetBudgetByMonth(ctx *abstraction.Context, user_id *int, from time.Time) (data []*model.RequestBudgetByMonth, err error)
 }
 
 type dashboard struct {
@@ -27,7 +32,7 @@ func NewDashboard(db *gorm.DB) *dashboard {
 func (r *dashboard) GetByStatus(ctx *abstraction.Context, user_id *int) (data []*model.RequestCountByStatus, err error) {
 	conn := r.CheckTrx(ctx)
 	query := conn.Table("request AS r").
-		Where("r.is_delete = ?", false)
+		Where("r.is_delete = ? AND r.status_id <> ?", false, constant.STATUS_ID_DRAFT)
 
 	if user_id != nil {
 		query = query.Where("r.user_id = ?", *user_id)
@@ -43,7 +48,7 @@ func (r *dashboard) GetByStatus(ctx *abstraction.Context, user_id *int) (data []
 func (r *dashboard) GetByEventType(ctx *abstraction.Context, user_id *int) (data []*model.RequestCountByEventType, err error) {
 	conn := r.CheckTrx(ctx)
 	query := conn.Table("request AS r").
-		Where("r.is_delete = ?", false)
+		Where("r.is_delete = ? AND r.status_id <> ?", false, constant.STATUS_ID_DRAFT)
 
 	if user_id != nil {
 		query = query.Where("r.user_id = ?", *user_id)
@@ -55,3 +60,53 @@ func (r *dashboard) GetByEventType(ctx *abstraction.Context, user_id *int) (data
 
 	return
 }
+
+func (r *dashboard) CountOverdue(ctx *abstraction.Context, user_id *int) (data int, err error) {
+	conn := r.CheckTrx(ctx)
+	query := conn.Table("request AS r").
+		Where("r.is_delete = ? AND r.is_overdue = ?", false, true)
+
+	if user_id != nil {
+		query = query.Where("r.user_id = ?", *user_id)
+	}
+
+	var count model.RequestCountDataModel
+	err = query.Select("COUNT(r.id) AS count").
+		Scan(&count).Error
+	data = count.Count
+
+	return
+}
+
+// budgetRequestQuery: anggaran hanya dihitung dari request yang sudah diajukan dan tidak ditolak/dibatalkan
+func (r *dashboard) budgetRequestQuery(ctx *abstraction.Context, user_id *int) *gorm.DB {
+	query := r.CheckTrx(ctx).Table("request_budget AS b").
+		Joins("JOIN request AS r ON r.id = b.request_id").
+		Where("b.is_delete = ? AND r.is_delete = ?", false, false).
+		Where("r.status_id NOT IN ?", []int{constant.STATUS_ID_DRAFT, constant.STATUS_ID_DITOLAK, constant.STATUS_ID_DIBATALKAN})
+
+	if user_id != nil {
+		query = query.Where("r.user_id = ?", *user_id)
+	}
+	return query
+}
+
+func (r *dashboard) GetBudgetByEventType(ctx *abstraction.Context, user_id *int) (data []*model.RequestBudgetByEventType, err error) {
+	err = r.budgetRequestQuery(ctx, user_id).
+		Select("r.event_type_id, COALESCE(SUM(b.estimated_amount), 0) AS estimated, COALESCE(SUM(b.actual_amount), 0) AS actual").
+		Group("r.event_type_id").
+		Scan(&data).Error
+
+	return
+}
+
+func (r *dashboard) GetBudgetByMonth(ctx *abstraction.Context, user_id *int, from time.Time) (data []*model.RequestBudgetByMonth, err error) {
+	err = r.budgetRequestQuery(ctx, user_id).
+		Where("r.event_date_start >= ?", from).
+		Select("DATE_FORMAT(r.event_date_start, '%Y-%m') AS month, COALESCE(SUM(b.estimated_amount), 0) AS estimated, COALESCE(SUM(b.actual_amount), 0) AS actual").
+		Group("month").
+		Order("month ASC").
+		Scan(&data).Error
+
+	return
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type Delegation interface {
+	Create(ctx *abstraction.Context, data *model.DelegationEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.DelegationEntityModel, error)
+	FindByUserId(ctx *abstraction.Context, user_id int) (data []*model.DelegationEntityModel, err error)
+	Update(ctx *abstraction.Context, data *model.DelegationEntityModel) *gorm.DB
+	FindOverlap(ctx *abstraction.Context, user_id int, start time.Time, end time.Time) (data []*model.DelegationEntityModel, err error)
+	FindActiveByUserId(ctx *abstraction.Context, user_id int, at time.Time) (*model.DelegationEntityModel, error)
+	FindActiveByDelegateId(ctx *abstraction.Context, delegate_id int, at time.Time) (data []*model.DelegationEntityModel, err error)
+}
+
+type delegation struct {
+	abstraction.Repository
+}
+
+func NewDelegation(db *gorm.DB) *delegation {
+	return &delegation{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *delegation) Create(ctx *abstraction.Context, data *model.DelegationEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *delegation) FindById(ctx *abstraction.Context, id int) (*model.DelegationEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.DelegationEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// FindByUserId mengambil delegasi yang dibuat user maupun yang ditujukan ke user tersebut
+func (r *delegation) FindByUserId(ctx *abstraction.Context, user_id int) (data []*model.DelegationEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ? AND (user_id = ? OR delegate_id = ?)", false, user_id, user_id).
+		Order("date_start DESC").
+		Preload("User").
+		Preload("Delegate").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *delegation) Update(ctx *abstraction.Context, data *model.DelegationEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+// FindOverlap mengambil delegasi milik user yang rentangnya beririsan dengan start-end
+func (r *delegation) FindOverlap(ctx *abstraction.Context, user_id int, start time.Time, end time.Time) (data []*model.DelegationEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ? AND user_id = ?", false, user_id).
+		Where("date_start < ? AND date_end > ?", end, start).
+		Find(&data).
+		Error
+	return
+}
+
+func (r *delegation) FindActiveByUserId(ctx *abstraction.Context, user_id int, at time.Time) (*model.DelegationEntityModel, error) {
+	var data model.DelegationEntityModel
+	err := r.CheckTrx(ctx).
+		Where("is_delete = ? AND user_id = ? AND date_start <= ? AND date_end > ?", false, user_id, at, at).
+		Preload("User").
+		Preload("Delegate").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+// FindActiveByDelegateId mengambil user yang sedang diwakili oleh delegate_id
+func (r *delegation) FindActiveByDelegateId(ctx *abstraction.Context, delegate_id int, at time.Time) (data []*model.DelegationEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ? AND delegate_id = ? AND date_start <= ? AND date_end > ?", false, delegate_id, at, at).
+		Preload("User").
+		Find(&data).
+		Error
+	return
+}
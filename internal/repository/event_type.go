@@ -14,6 +14,11 @@ type EventType interface {
 	Create(ctx *abstraction.Context, data *model.EventTypeEntityModel) *gorm.DB
 	FindById(ctx *abstraction.Context, id int) (*model.EventTypeEntityModel, error)
 	Update(ctx *abstraction.Context, data *model.EventTypeEntityModel) *gorm.DB
+	UpdateWithVersion(ctx *abstraction.Context, data *model.EventTypeEntityModel, version int) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	FindTrash(ctx *abstraction.Context, no_paging bool) (data []*model.EventTypeEntityModel, err error)
+	CountTrash(ctx *abstraction.Context) (data *int, err error)
+	FindDeletedById(ctx *abstraction.Context, id int) (*model.EventTypeEntityModel, error)
 }
 
 type event_type struct {
@@ -76,3 +81,52 @@ func (r *event_type) FindById(ctx *abstraction.Context, id int) (*model.EventTyp
 func (r *event_type) Update(ctx *abstraction.Context, data *model.EventTypeEntityModel) *gorm.DB {
 	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
 }
+
+// UpdateWithVersion hanya mengupdate jika version di db masih sama lalu menaikkan version,
+// RowsAffected 0 berarti data sudah diubah oleh user lain
+func (r *event_type) UpdateWithVersion(ctx *abstraction.Context, data *model.EventTypeEntityModel, version int) *gorm.DB {
+	data.Version = version + 1
+	return r.CheckTrx(ctx).Model(data).Where("id = ? AND version = ?", data.ID, version).Updates(data)
+}
+
+func (r *event_type) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.EventTypeEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+func (r *event_type) FindTrash(ctx *abstraction.Context, no_paging bool) (data []*model.EventTypeEntityModel, err error) {
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ?", true).
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Find(&data).
+		Error
+	return
+}
+
+func (r *event_type) CountTrash(ctx *abstraction.Context) (data *int, err error) {
+	var count model.EventTypeCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("event_type").
+		Select("COUNT(*) AS count").
+		Where("is_delete = ?", true).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *event_type) FindDeletedById(ctx *abstraction.Context, id int) (*model.EventTypeEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.EventTypeEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, true).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
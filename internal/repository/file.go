@@ -15,6 +15,9 @@ type File interface {
 	Create(ctx *abstraction.Context, data *model.FileEntityModel) *gorm.DB
 	FindById(ctx *abstraction.Context, id int) (*model.FileEntityModel, error)
 	Update(ctx *abstraction.Context, data *model.FileEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	FindTrashByRequestId(ctx *abstraction.Context, request_id int) (data []*model.FileEntityModel, err error)
+	FindDeletedById(ctx *abstraction.Context, id int) (*model.FileEntityModel, error)
 }
 
 type file struct {
@@ -77,3 +80,30 @@ func (r *file) FindById(ctx *abstraction.Context, id int) (*model.FileEntityMode
 func (r *file) Update(ctx *abstraction.Context, data *model.FileEntityModel) *gorm.DB {
 	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
 }
+
+func (r *file) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.FileEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+func (r *file) FindTrashByRequestId(ctx *abstraction.Context, request_id int) (data []*model.FileEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, true).
+		Order("deleted_at DESC, id DESC").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *file) FindDeletedById(ctx *abstraction.Context, id int) (*model.FileEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.FileEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, true).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
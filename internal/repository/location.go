@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+
+	"gorm.io/gorm"
+)
+
+type Location interface {
+	Find(ctx *abstraction.Context, no_paging bool) (data []*model.LocationEntityModel, err error)
+	Count(ctx *abstraction.Context) (data *int, err error)
+	Create(ctx *abstraction.Context, data *model.LocationEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.LocationEntityModel, error)
+	FindByName(ctx *abstraction.Context, name string) (*model.LocationEntityModel, error)
+	Update(ctx *abstraction.Context, data *model.LocationEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+}
+
+type location struct {
+	abstraction.Repository
+}
+
+func NewLocation(db *gorm.DB) *location {
+	return &location{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *location) Find(ctx *abstraction.Context, no_paging bool) (data []*model.LocationEntityModel, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "location", "is_delete = @false")
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	order := general.ProcessOrder(ctx)
+	err = r.CheckTrx(ctx).
+		Where(where, whereParam).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Find(&data).
+		Error
+	return
+}
+
+func (r *location) Count(ctx *abstraction.Context) (data *int, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "location", "is_delete = @false")
+	var count model.LocationCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("location").
+		Select("COUNT(*) AS count").
+		Where(where, whereParam).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *location) Create(ctx *abstraction.Context, data *model.LocationEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *location) FindById(ctx *abstraction.Context, id int) (*model.LocationEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.LocationEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *location) FindByName(ctx *abstraction.Context, name string) (*model.LocationEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.LocationEntityModel
+	err := conn.
+		Where("LOWER(TRIM(name)) = LOWER(TRIM(?)) AND is_delete = ?", name, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *location) Update(ctx *abstraction.Context, data *model.LocationEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+func (r *location) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.LocationEntityModel{}).Where("id = ?", id).Updates(data)
+}
@@ -89,3 +89,31 @@ func (r *notification) FindByUserIdArr(ctx *abstraction.Context, userId *int) (d
 		Error
 	return
 }
+
+// SendNotification membuat notifikasi untuk userId lalu mengembalikan id penerima akhirnya, yaitu delegasi jika
+// user sedang cuti. Id tersebut yang dipublish ke websocket setelah transaksi selesai
+func SendNotification(ctx *abstraction.Context, notification Notification, delegation Delegation, title string, message string, userId int, requestId int) (int, error) {
+	delegationData, err := delegation.FindActiveByUserId(ctx, userId, *general.NowWithLocation())
+	if err != nil && err.Error() != "record not found" {
+		return 0, err
+	}
+	if delegationData != nil {
+		userId = delegationData.DelegateId
+		message = fmt.Sprintf("%s (a.n. %s)", message, delegationData.User.Name)
+	}
+
+	modelNotification := &model.NotificationEntityModel{
+		Context: ctx,
+		NotificationEntity: model.NotificationEntity{
+			Title:     title,
+			Message:   message,
+			IsRead:    false,
+			UserId:    userId,
+			RequestId: requestId,
+		},
+	}
+	if err := notification.Create(ctx, modelNotification).Error; err != nil {
+		return 0, err
+	}
+	return userId, nil
+}
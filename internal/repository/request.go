@@ -3,7 +3,9 @@ package repository
 import (
 	"bm_binus/internal/abstraction"
 	"bm_binus/internal/model"
+	"bm_binus/pkg/constant"
 	"bm_binus/pkg/util/general"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -13,7 +15,21 @@ type Request interface {
 	FindById(ctx *abstraction.Context, id int) (*model.RequestEntityModel, error)
 	Find(ctx *abstraction.Context, no_paging bool) (data []*model.RequestEntityModel, err error)
 	Count(ctx *abstraction.Context) (data *int, err error)
+	FindRankInput(ctx *abstraction.Context, status_ids []int) (data []*model.RequestRankInputModel, err error)
+	FindByIds(ctx *abstraction.Context, ids []int) (data []*model.RequestEntityModel, err error)
 	Update(ctx *abstraction.Context, data *model.RequestEntityModel) *gorm.DB
+	UpdateWithVersion(ctx *abstraction.Context, data *model.RequestEntityModel, version int) *gorm.DB
+	FindOverlap(ctx *abstraction.Context, location_id int, start time.Time, end time.Time, exclude_id int, exclude_status []int) (data []*model.RequestEntityModel, err error)
+	FindUnlinkedLocation(ctx *abstraction.Context) (data []string, err error)
+	LinkLocation(ctx *abstraction.Context, location string, location_id int) *gorm.DB
+	UpdateLocationName(ctx *abstraction.Context, location_id int, name string) *gorm.DB
+	FindBySeriesId(ctx *abstraction.Context, series_id int, from_index int) (data []*model.RequestEntityModel, err error)
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	FindSlaCandidate(ctx *abstraction.Context, status_ids []int) (data []*model.RequestEntityModel, err error)
+	CountByAssignee(ctx *abstraction.Context, status_ids []int) (data []*model.RequestCountByAssignee, err error)
+	FindTrash(ctx *abstraction.Context, user_id *int, no_paging bool) (data []*model.RequestEntityModel, err error)
+	CountTrash(ctx *abstraction.Context, user_id *int) (data *int, err error)
+	FindDeletedById(ctx *abstraction.Context, id int) (*model.RequestEntityModel, error)
 }
 
 type request struct {
@@ -35,12 +51,19 @@ func (r *request) Create(ctx *abstraction.Context, data *model.RequestEntityMode
 func (r *request) FindById(ctx *abstraction.Context, id int) (*model.RequestEntityModel, error) {
 	conn := r.CheckTrx(ctx)
 
+	// draft hanya bisa diakses oleh pemiliknya
+	if ctx.Auth != nil {
+		conn = conn.Where("status_id <> ? OR user_id = ?", constant.STATUS_ID_DRAFT, ctx.Auth.ID)
+	}
+
 	var data model.RequestEntityModel
 	err := conn.
 		Where("id = ? AND is_delete = ?", id, false).
 		Preload("User").
 		Preload("EventType").
 		Preload("Status").
+		Preload("Location").
+		Preload("Assignee").
 		First(&data).
 		Error
 	if err != nil {
@@ -49,8 +72,24 @@ func (r *request) FindById(ctx *abstraction.Context, id int) (*model.RequestEnti
 	return &data, nil
 }
 
+// requestWhere: draft tidak ikut di listing biasa, hanya tampil untuk pemiliknya lewat query draft=yes,
+// assigned_to_me=yes membatasi ke request yang ditugaskan ke user login
+func requestWhere(ctx *abstraction.Context) (string, map[string]interface{}) {
+	whereStr := "is_delete = @false AND status_id <> @status_draft"
+	if ctx.QueryParam("draft") == "yes" {
+		whereStr = "is_delete = @false AND status_id = @status_draft AND user_id = @auth_user_id"
+	}
+	if ctx.QueryParam("assigned_to_me") == "yes" {
+		whereStr += " AND assignee_id = @auth_user_id"
+	}
+	where, whereParam := general.ProcessWhereParam(ctx, "request", whereStr)
+	whereParam["status_draft"] = constant.STATUS_ID_DRAFT
+	whereParam["auth_user_id"] = ctx.Auth.ID
+	return where, whereParam
+}
+
 func (r *request) Find(ctx *abstraction.Context, no_paging bool) (data []*model.RequestEntityModel, err error) {
-	where, whereParam := general.ProcessWhereParam(ctx, "request", "is_delete = @false")
+	where, whereParam := requestWhere(ctx)
 	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
 	order := general.ProcessOrder(ctx)
 	err = r.CheckTrx(ctx).
@@ -61,13 +100,15 @@ func (r *request) Find(ctx *abstraction.Context, no_paging bool) (data []*model.
 		Preload("User").
 		Preload("EventType").
 		Preload("Status").
+		Preload("Location").
+		Preload("Assignee").
 		Find(&data).
 		Error
 	return
 }
 
 func (r *request) Count(ctx *abstraction.Context) (data *int, err error) {
-	where, whereParam := general.ProcessWhereParam(ctx, "request", "is_delete = @false")
+	where, whereParam := requestWhere(ctx)
 	var count model.RequestCountDataModel
 	err = r.CheckTrx(ctx).
 		Table("request").
@@ -79,6 +120,182 @@ func (r *request) Count(ctx *abstraction.Context) (data *int, err error) {
 	return
 }
 
+// FindRankInput mengambil input ranking AHP untuk semua request berstatus status_ids sesuai filter listing,
+// tanpa paging/order dari query param
+func (r *request) FindRankInput(ctx *abstraction.Context, status_ids []int) (data []*model.RequestRankInputModel, err error) {
+	where, whereParam := requestWhere(ctx)
+	err = r.CheckTrx(ctx).
+		Table("request").
+		Select("id, event_name, event_date_start, count_participant, created_at, (SELECT priority FROM event_type WHERE event_type.id = request.event_type_id) AS event_type_priority").
+		Where(where, whereParam).
+		Where("status_id IN ?", status_ids).
+		Order("id ASC").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request) FindByIds(ctx *abstraction.Context, ids []int) (data []*model.RequestEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("id IN ? AND is_delete = ?", ids, false).
+		Preload("User").
+		Preload("EventType").
+		Preload("Status").
+		Preload("Location").
+		Preload("Assignee").
+		Find(&data).
+		Error
+	return
+}
+
 func (r *request) Update(ctx *abstraction.Context, data *model.RequestEntityModel) *gorm.DB {
 	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
 }
+
+// UpdateWithVersion hanya mengupdate jika version di db masih sama lalu menaikkan version,
+// RowsAffected 0 berarti data sudah diubah oleh user lain
+func (r *request) UpdateWithVersion(ctx *abstraction.Context, data *model.RequestEntityModel, version int) *gorm.DB {
+	data.Version = version + 1
+	return r.CheckTrx(ctx).Model(data).Where("id = ? AND version = ?", data.ID, version).Updates(data)
+}
+
+// FindOverlap mencari request di lokasi yang sama yang rentang waktunya (termasuk buffer
+// setup/teardown dari event type masing-masing) beririsan dengan rentang start-end
+func (r *request) FindOverlap(ctx *abstraction.Context, location_id int, start time.Time, end time.Time, exclude_id int, exclude_status []int) (data []*model.RequestEntityModel, err error) {
+	query := r.CheckTrx(ctx).
+		Select("request.*").
+		Joins("JOIN event_type ON event_type.id = request.event_type_id").
+		Where("request.is_delete = ? AND request.id <> ?", false, exclude_id).
+		Where("request.location_id = ?", location_id).
+		Where("DATE_SUB(request.event_date_start, INTERVAL event_type.setup_minutes MINUTE) < ?", end).
+		Where("DATE_ADD(request.event_date_end, INTERVAL event_type.teardown_minutes MINUTE) > ?", start)
+	if len(exclude_status) > 0 {
+		query = query.Where("request.status_id NOT IN ?", exclude_status)
+	}
+	err = query.
+		Order("request.event_date_start ASC").
+		Preload("EventType").
+		Find(&data).
+		Error
+	return
+}
+
+// FindUnlinkedLocation mengambil nama lokasi free-text yang belum terhubung ke tabel location
+func (r *request) FindUnlinkedLocation(ctx *abstraction.Context) (data []string, err error) {
+	err = r.CheckTrx(ctx).
+		Model(&model.RequestEntityModel{}).
+		Distinct("TRIM(event_location)").
+		Where("location_id IS NULL AND TRIM(event_location) <> ''").
+		Pluck("TRIM(event_location)", &data).
+		Error
+	return
+}
+
+func (r *request) LinkLocation(ctx *abstraction.Context, location string, location_id int) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.RequestEntityModel{}).
+		Where("location_id IS NULL AND LOWER(TRIM(event_location)) = LOWER(TRIM(?))", location).
+		Update("location_id", location_id)
+}
+
+func (r *request) UpdateLocationName(ctx *abstraction.Context, location_id int, name string) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.RequestEntityModel{}).
+		Where("location_id = ?", location_id).
+		Update("event_location", name)
+}
+
+// FindBySeriesId mengambil kejadian dalam satu seri mulai dari urutan from_index
+func (r *request) FindBySeriesId(ctx *abstraction.Context, series_id int, from_index int) (data []*model.RequestEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("series_id = ? AND series_index >= ? AND is_delete = ?", series_id, from_index, false).
+		Order("series_index ASC").
+		Preload("User").
+		Preload("EventType").
+		Preload("Status").
+		Preload("Location").
+		Preload("Assignee").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.RequestEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+// FindSlaCandidate mengambil request aktif pada status yang punya SLA dan belum ditandai overdue
+func (r *request) FindSlaCandidate(ctx *abstraction.Context, status_ids []int) (data []*model.RequestEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ? AND is_overdue = ? AND status_id IN ?", false, false, status_ids).
+		Preload("Status").
+		Find(&data).
+		Error
+	return
+}
+
+// CountByAssignee menghitung beban request per assignee pada status yang masih berjalan
+func (r *request) CountByAssignee(ctx *abstraction.Context, status_ids []int) (data []*model.RequestCountByAssignee, err error) {
+	err = r.CheckTrx(ctx).
+		Table("request").
+		Select("assignee_id, COUNT(*) AS total").
+		Where("is_delete = ? AND assignee_id IS NOT NULL AND status_id IN ?", false, status_ids).
+		Group("assignee_id").
+		Find(&data).
+		Error
+	return
+}
+
+// trashQuery: request yang sudah dihapus, user_id diisi untuk membatasi ke request milik user tersebut
+func (r *request) trashQuery(ctx *abstraction.Context, user_id *int) *gorm.DB {
+	query := r.CheckTrx(ctx).Where("is_delete = ?", true)
+	if user_id != nil {
+		query = query.Where("user_id = ?", *user_id)
+	}
+	return query
+}
+
+func (r *request) FindTrash(ctx *abstraction.Context, user_id *int, no_paging bool) (data []*model.RequestEntityModel, err error) {
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	err = r.trashQuery(ctx, user_id).
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Preload("User").
+		Preload("EventType").
+		Preload("Status").
+		Preload("Location").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request) CountTrash(ctx *abstraction.Context, user_id *int) (data *int, err error) {
+	var count model.RequestCountDataModel
+	err = r.trashQuery(ctx, user_id).
+		Table("request").
+		Select("COUNT(*) AS count").
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *request) FindDeletedById(ctx *abstraction.Context, id int) (*model.RequestEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, true).
+		Preload("User").
+		Preload("EventType").
+		Preload("Status").
+		Preload("Location").
+		Preload("Assignee").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
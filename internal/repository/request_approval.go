@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type RequestApproval interface {
+	Create(ctx *abstraction.Context, data *model.RequestApprovalEntityModel) *gorm.DB
+	FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestApprovalEntityModel, err error)
+	Update(ctx *abstraction.Context, data *model.RequestApprovalEntityModel) *gorm.DB
+	DeleteByRequestId(ctx *abstraction.Context, request_id int) *gorm.DB
+}
+
+type request_approval struct {
+	abstraction.Repository
+}
+
+func NewRequestApproval(db *gorm.DB) *request_approval {
+	return &request_approval{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_approval) Create(ctx *abstraction.Context, data *model.RequestApprovalEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_approval) FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestApprovalEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Order("step_order ASC, id ASC").
+		Preload("Role").
+		Preload("User").
+		Preload("DecidedByUser").
+		Preload("OnBehalf").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_approval) Update(ctx *abstraction.Context, data *model.RequestApprovalEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+// DeleteByRequestId menutup rantai persetujuan lama saat request masuk validasi ulang
+func (r *request_approval) DeleteByRequestId(ctx *abstraction.Context, request_id int) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.RequestApprovalEntityModel{}).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Update("is_delete", true)
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+type RequestAsset interface {
+	Create(ctx *abstraction.Context, data *model.RequestAssetEntityModel) *gorm.DB
+	FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestAssetEntityModel, err error)
+	DeleteByRequestId(ctx *abstraction.Context, request_id int) *gorm.DB
+	SumReserved(ctx *abstraction.Context, asset_ids []int, start time.Time, end time.Time, exclude_id int, status_ids []int) (data []*model.AssetReserved, err error)
+}
+
+type request_asset struct {
+	abstraction.Repository
+}
+
+func NewRequestAsset(db *gorm.DB) *request_asset {
+	return &request_asset{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_asset) Create(ctx *abstraction.Context, data *model.RequestAssetEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_asset) FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestAssetEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Order("id ASC").
+		Preload("Asset").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_asset) DeleteByRequestId(ctx *abstraction.Context, request_id int) *gorm.DB {
+	return r.CheckTrx(ctx).
+		Model(&model.RequestAssetEntityModel{}).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Update("is_delete", true)
+}
+
+// SumReserved menjumlahkan pesanan aset dari request dengan status_ids yang rentang waktunya (termasuk buffer
+// setup/teardown event type) beririsan dengan start-end, asset_ids kosong berarti semua aset
+func (r *request_asset) SumReserved(ctx *abstraction.Context, asset_ids []int, start time.Time, end time.Time, exclude_id int, status_ids []int) (data []*model.AssetReserved, err error) {
+	query := r.CheckTrx(ctx).
+		Table("request_asset").
+		Select("request_asset.asset_id AS asset_id, SUM(request_asset.quantity) AS total").
+		Joins("JOIN request ON request.id = request_asset.request_id").
+		Joins("JOIN event_type ON event_type.id = request.event_type_id").
+		Where("request_asset.is_delete = ? AND request.is_delete = ? AND request.id <> ?", false, false, exclude_id).
+		Where("request.status_id IN ?", status_ids).
+		Where("DATE_SUB(request.event_date_start, INTERVAL event_type.setup_minutes MINUTE) < ?", end).
+		Where("DATE_ADD(request.event_date_end, INTERVAL event_type.teardown_minutes MINUTE) > ?", start)
+	if len(asset_ids) > 0 {
+		query = query.Where("request_asset.asset_id IN ?", asset_ids)
+	}
+	err = query.
+		Group("request_asset.asset_id").
+		Find(&data).
+		Error
+	return
+}
@@ -0,0 +1,79 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type RequestBudget interface {
+	Create(ctx *abstraction.Context, data *model.RequestBudgetEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.RequestBudgetEntityModel, error)
+	FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestBudgetEntityModel, err error)
+	Update(ctx *abstraction.Context, data *model.RequestBudgetEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	SumByRequestIds(ctx *abstraction.Context, request_ids []int) (data []*model.RequestBudgetTotal, err error)
+}
+
+type request_budget struct {
+	abstraction.Repository
+}
+
+func NewRequestBudget(db *gorm.DB) *request_budget {
+	return &request_budget{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_budget) Create(ctx *abstraction.Context, data *model.RequestBudgetEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_budget) FindById(ctx *abstraction.Context, id int) (*model.RequestBudgetEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestBudgetEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *request_budget) FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestBudgetEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Order("category ASC, id ASC").
+		Preload("CreateBy").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_budget) Update(ctx *abstraction.Context, data *model.RequestBudgetEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+func (r *request_budget) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.RequestBudgetEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+func (r *request_budget) SumByRequestIds(ctx *abstraction.Context, request_ids []int) (data []*model.RequestBudgetTotal, err error) {
+	if len(request_ids) == 0 {
+		return
+	}
+	err = r.CheckTrx(ctx).
+		Table("request_budget").
+		Select("request_id, COALESCE(SUM(estimated_amount), 0) AS estimated, COALESCE(SUM(actual_amount), 0) AS actual").
+		Where("request_id IN ? AND is_delete = ?", request_ids, false).
+		Group("request_id").
+		Scan(&data).
+		Error
+	return
+}
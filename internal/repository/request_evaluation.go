@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type RequestEvaluation interface {
+	Create(ctx *abstraction.Context, data *model.RequestEvaluationEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.RequestEvaluationEntityModel, error)
+	FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestEvaluationEntityModel, err error)
+	FindByRequestIdAndUserId(ctx *abstraction.Context, request_id int, user_id int) (*model.RequestEvaluationEntityModel, error)
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	Summarize(ctx *abstraction.Context, group_column string, request_id *int) (data []*model.RequestEvaluationSummary, err error)
+}
+
+type request_evaluation struct {
+	abstraction.Repository
+}
+
+func NewRequestEvaluation(db *gorm.DB) *request_evaluation {
+	return &request_evaluation{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_evaluation) Create(ctx *abstraction.Context, data *model.RequestEvaluationEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_evaluation) FindById(ctx *abstraction.Context, id int) (*model.RequestEvaluationEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestEvaluationEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *request_evaluation) FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestEvaluationEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Order("created_at ASC, id ASC").
+		Preload("User").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_evaluation) FindByRequestIdAndUserId(ctx *abstraction.Context, request_id int, user_id int) (*model.RequestEvaluationEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestEvaluationEntityModel
+	err := conn.
+		Where("request_id = ? AND user_id = ? AND is_delete = ?", request_id, user_id, false).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *request_evaluation) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.RequestEvaluationEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+// Summarize merata-ratakan evaluasi per group_column milik tabel request (id, location_id atau event_type_id),
+// request_id diisi untuk membatasi ke satu request saja
+func (r *request_evaluation) Summarize(ctx *abstraction.Context, group_column string, request_id *int) (data []*model.RequestEvaluationSummary, err error) {
+	query := r.CheckTrx(ctx).
+		Table("request_evaluation AS e").
+		Joins("JOIN request AS r ON r.id = e.request_id").
+		Where("e.is_delete = ? AND r.is_delete = ?", false, false)
+	if group_column == "location_id" {
+		query = query.Where("r.location_id IS NOT NULL")
+	}
+	if request_id != nil {
+		query = query.Where("r.id = ?", *request_id)
+	}
+	err = query.
+		Select("r." + group_column + " AS group_id, COUNT(e.id) AS count, " +
+			"AVG(e.rating_location) AS rating_location, AVG(e.rating_facility) AS rating_facility, " +
+			"AVG(e.rating_service) AS rating_service, AVG(e.rating_overall) AS rating_overall, " +
+			"COALESCE(AVG(e.actual_attendance), 0) AS actual_attendance").
+		Group("r." + group_column).
+		Scan(&data).
+		Error
+	return
+}
@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type RequestSeries interface {
+	Create(ctx *abstraction.Context, data *model.RequestSeriesEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.RequestSeriesEntityModel, error)
+}
+
+type request_series struct {
+	abstraction.Repository
+}
+
+func NewRequestSeries(db *gorm.DB) *request_series {
+	return &request_series{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_series) Create(ctx *abstraction.Context, data *model.RequestSeriesEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_series) FindById(ctx *abstraction.Context, id int) (*model.RequestSeriesEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestSeriesEntityModel
+	err := conn.
+		Where("id = ?", id).
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+
+	"gorm.io/gorm"
+)
+
+type RequestStatusHistory interface {
+	Create(ctx *abstraction.Context, data *model.RequestStatusHistoryEntityModel) *gorm.DB
+	FindByRequestId(ctx *abstraction.Context, request_id int, no_paging bool) (data []*model.RequestStatusHistoryEntityModel, err error)
+	CountByRequestId(ctx *abstraction.Context, request_id int) (data *int, err error)
+}
+
+type request_status_history struct {
+	abstraction.Repository
+}
+
+func NewRequestStatusHistory(db *gorm.DB) *request_status_history {
+	return &request_status_history{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_status_history) Create(ctx *abstraction.Context, data *model.RequestStatusHistoryEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_status_history) FindByRequestId(ctx *abstraction.Context, request_id int, no_paging bool) (data []*model.RequestStatusHistoryEntityModel, err error) {
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	order := "created_at ASC, id ASC"
+	err = r.CheckTrx(ctx).
+		Where("request_id = ?", request_id).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Preload("FromStatus").
+		Preload("ToStatus").
+		Preload("CreateBy").
+		Preload("CreateBy.Role").
+		Preload("OnBehalf").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_status_history) CountByRequestId(ctx *abstraction.Context, request_id int) (data *int, err error) {
+	var count model.RequestStatusHistoryCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("request_status_history").
+		Select("COUNT(*) AS count").
+		Where("request_id = ?", request_id).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+
+	"gorm.io/gorm"
+)
+
+type RequestTask interface {
+	Create(ctx *abstraction.Context, data *model.RequestTaskEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.RequestTaskEntityModel, error)
+	FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestTaskEntityModel, err error)
+	CountByRequestId(ctx *abstraction.Context, request_id int) (data *int, err error)
+	CountRequiredUndone(ctx *abstraction.Context, request_id int) (data *int, err error)
+	Update(ctx *abstraction.Context, data *model.RequestTaskEntityModel) *gorm.DB
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+}
+
+type request_task struct {
+	abstraction.Repository
+}
+
+func NewRequestTask(db *gorm.DB) *request_task {
+	return &request_task{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *request_task) Create(ctx *abstraction.Context, data *model.RequestTaskEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_task) FindById(ctx *abstraction.Context, id int) (*model.RequestTaskEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestTaskEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		Preload("Assignee").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *request_task) FindByRequestId(ctx *abstraction.Context, request_id int) (data []*model.RequestTaskEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Order("due_date IS NULL, due_date ASC, id ASC").
+		Preload("Assignee").
+		Preload("DoneByUser").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_task) CountByRequestId(ctx *abstraction.Context, request_id int) (data *int, err error) {
+	var count model.RequestTaskCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("request_task").
+		Select("COUNT(*) AS count").
+		Where("request_id = ? AND is_delete = ?", request_id, false).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *request_task) CountRequiredUndone(ctx *abstraction.Context, request_id int) (data *int, err error) {
+	var count model.RequestTaskCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("request_task").
+		Select("COUNT(*) AS count").
+		Where("request_id = ? AND is_required = ? AND is_done = ? AND is_delete = ?", request_id, true, false, false).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *request_task) Update(ctx *abstraction.Context, data *model.RequestTaskEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
+
+func (r *request_task) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.RequestTaskEntityModel{}).Where("id = ?", id).Updates(data)
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+
+	"gorm.io/gorm"
+)
+
+type RequestTemplate interface {
+	Find(ctx *abstraction.Context, no_paging bool) (data []*model.RequestTemplateEntityModel, err error)
+	Count(ctx *abstraction.Context) (data *int, err error)
+	Create(ctx *abstraction.Context, data *model.RequestTemplateEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.RequestTemplateEntityModel, error)
+	Update(ctx *abstraction.Context, data *model.RequestTemplateEntityModel) *gorm.DB
+}
+
+type request_template struct {
+	abstraction.Repository
+}
+
+func NewRequestTemplate(db *gorm.DB) *request_template {
+	return &request_template{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+// template bersifat pribadi, hanya milik user yang login
+func requestTemplateWhere(ctx *abstraction.Context) (string, map[string]interface{}) {
+	where, whereParam := general.ProcessWhereParam(ctx, "request_template", "is_delete = @false AND user_id = @auth_user_id")
+	whereParam["auth_user_id"] = ctx.Auth.ID
+	return where, whereParam
+}
+
+func (r *request_template) Find(ctx *abstraction.Context, no_paging bool) (data []*model.RequestTemplateEntityModel, err error) {
+	where, whereParam := requestTemplateWhere(ctx)
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	order := general.ProcessOrder(ctx)
+	err = r.CheckTrx(ctx).
+		Where(where, whereParam).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Preload("EventType").
+		Preload("Location").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *request_template) Count(ctx *abstraction.Context) (data *int, err error) {
+	where, whereParam := requestTemplateWhere(ctx)
+	var count model.RequestTemplateCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("request_template").
+		Select("COUNT(*) AS count").
+		Where(where, whereParam).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *request_template) Create(ctx *abstraction.Context, data *model.RequestTemplateEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *request_template) FindById(ctx *abstraction.Context, id int) (*model.RequestTemplateEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.RequestTemplateEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		Preload("EventType").
+		Preload("Location").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *request_template) Update(ctx *abstraction.Context, data *model.RequestTemplateEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"bm_binus/pkg/util/general"
+
+	"gorm.io/gorm"
+)
+
+type Sla interface {
+	Find(ctx *abstraction.Context, no_paging bool) (data []*model.SlaEntityModel, err error)
+	Count(ctx *abstraction.Context) (data *int, err error)
+	Create(ctx *abstraction.Context, data *model.SlaEntityModel) *gorm.DB
+	FindById(ctx *abstraction.Context, id int) (*model.SlaEntityModel, error)
+	FindByStatusAndEventType(ctx *abstraction.Context, status_id int, event_type_id *int) (*model.SlaEntityModel, error)
+	FindActive(ctx *abstraction.Context) (data []*model.SlaEntityModel, err error)
+	Update(ctx *abstraction.Context, data *model.SlaEntityModel) *gorm.DB
+}
+
+type sla struct {
+	abstraction.Repository
+}
+
+func NewSla(db *gorm.DB) *sla {
+	return &sla{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *sla) Find(ctx *abstraction.Context, no_paging bool) (data []*model.SlaEntityModel, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "sla", "is_delete = @false")
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	order := general.ProcessOrder(ctx)
+	err = r.CheckTrx(ctx).
+		Where(where, whereParam).
+		Order(order).
+		Limit(limit).
+		Offset(offset).
+		Preload("Status").
+		Preload("EventType").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *sla) Count(ctx *abstraction.Context) (data *int, err error) {
+	where, whereParam := general.ProcessWhereParam(ctx, "sla", "is_delete = @false")
+	var count model.SlaCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("sla").
+		Select("COUNT(*) AS count").
+		Where(where, whereParam).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *sla) Create(ctx *abstraction.Context, data *model.SlaEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Create(data)
+}
+
+func (r *sla) FindById(ctx *abstraction.Context, id int) (*model.SlaEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.SlaEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, false).
+		Preload("Status").
+		Preload("EventType").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *sla) FindByStatusAndEventType(ctx *abstraction.Context, status_id int, event_type_id *int) (*model.SlaEntityModel, error) {
+	conn := r.CheckTrx(ctx).Where("status_id = ? AND is_delete = ?", status_id, false)
+	if event_type_id != nil {
+		conn = conn.Where("event_type_id = ?", *event_type_id)
+	} else {
+		conn = conn.Where("event_type_id IS NULL")
+	}
+
+	var data model.SlaEntityModel
+	err := conn.First(&data).Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
+
+func (r *sla) FindActive(ctx *abstraction.Context) (data []*model.SlaEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ?", false).
+		Preload("Status").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *sla) Update(ctx *abstraction.Context, data *model.SlaEntityModel) *gorm.DB {
+	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
+}
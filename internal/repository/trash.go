@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"bm_binus/internal/abstraction"
+	"bm_binus/internal/model"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// requestChildTables: tabel yang ikut dihapus permanen saat request di-purge
+var requestChildTables = []string{
+	"comment",
+	"file",
+	"notification",
+	"request_status_history",
+	"request_approval",
+	"request_task",
+	"request_asset",
+	"request_budget",
+	"request_evaluation",
+}
+
+type Trash interface {
+	FindExpiredRequestIds(ctx *abstraction.Context, before time.Time) (data []int, err error)
+	FindExpiredFiles(ctx *abstraction.Context, before time.Time, request_ids []int) (data []*model.FileEntityModel, err error)
+	FindReferencedDriveIds(ctx *abstraction.Context, drive_ids []string) (data []string, err error)
+	PurgeRequests(ctx *abstraction.Context, ids []int) error
+	PurgeComments(ctx *abstraction.Context, before time.Time) *gorm.DB
+	PurgeFiles(ctx *abstraction.Context, ids []int) *gorm.DB
+}
+
+type trash struct {
+	abstraction.Repository
+}
+
+func NewTrash(db *gorm.DB) *trash {
+	return &trash{
+		Repository: abstraction.Repository{
+			Db: db,
+		},
+	}
+}
+
+func (r *trash) FindExpiredRequestIds(ctx *abstraction.Context, before time.Time) (data []int, err error) {
+	err = r.CheckTrx(ctx).
+		Table("request").
+		Where("is_delete = ? AND deleted_at IS NOT NULL AND deleted_at < ?", true, before).
+		Pluck("id", &data).
+		Error
+	return
+}
+
+// FindExpiredFiles mengambil file yang sudah lewat masa simpan di trash ditambah semua file milik request yang akan di-purge
+func (r *trash) FindExpiredFiles(ctx *abstraction.Context, before time.Time, request_ids []int) (data []*model.FileEntityModel, err error) {
+	query := r.CheckTrx(ctx).
+		Where("is_delete = ? AND deleted_at IS NOT NULL AND deleted_at < ?", true, before)
+	if len(request_ids) > 0 {
+		query = query.Or("request_id IN ?", request_ids)
+	}
+	err = query.Find(&data).Error
+	return
+}
+
+// FindReferencedDriveIds mengembalikan id drive yang masih dipakai baris file lain (occurrence series berbagi id drive yang sama),
+// termasuk file di trash yang belum lewat masa simpan karena masih bisa di-restore
+func (r *trash) FindReferencedDriveIds(ctx *abstraction.Context, drive_ids []string) (data []string, err error) {
+	err = r.CheckTrx(ctx).
+		Table("file").
+		Where("file IN ?", drive_ids).
+		Distinct().
+		Pluck("file", &data).
+		Error
+	return
+}
+
+func (r *trash) PurgeRequests(ctx *abstraction.Context, ids []int) error {
+	conn := r.CheckTrx(ctx)
+	for _, table := range requestChildTables {
+		if err := conn.Exec("DELETE FROM "+table+" WHERE request_id IN ?", ids).Error; err != nil {
+			return err
+		}
+	}
+	return conn.Exec("DELETE FROM request WHERE id IN ?", ids).Error
+}
+
+func (r *trash) PurgeComments(ctx *abstraction.Context, before time.Time) *gorm.DB {
+	return r.CheckTrx(ctx).Exec("DELETE FROM comment WHERE is_delete = ? AND deleted_at IS NOT NULL AND deleted_at < ?", true, before)
+}
+
+func (r *trash) PurgeFiles(ctx *abstraction.Context, ids []int) *gorm.DB {
+	return r.CheckTrx(ctx).Exec("DELETE FROM file WHERE id IN ?", ids)
+}
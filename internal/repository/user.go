@@ -15,7 +15,13 @@ type User interface {
 	Count(ctx *abstraction.Context) (data *int, err error)
 	FindById(ctx *abstraction.Context, id int) (*model.UserEntityModel, error)
 	Update(ctx *abstraction.Context, data *model.UserEntityModel) *gorm.DB
+	UpdateWithVersion(ctx *abstraction.Context, data *model.UserEntityModel, version int) *gorm.DB
 	FindByRoleIdArr(ctx *abstraction.Context, role_id int, no_paging bool) (data []*model.UserEntityModel, err error)
+	FindByRoleId(ctx *abstraction.Context, role_id int) (data []*model.UserEntityModel, err error)
+	UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB
+	FindTrash(ctx *abstraction.Context, no_paging bool) (data []*model.UserEntityModel, err error)
+	CountTrash(ctx *abstraction.Context) (data *int, err error)
+	FindDeletedById(ctx *abstraction.Context, id int) (*model.UserEntityModel, error)
 }
 
 type user struct {
@@ -96,6 +102,13 @@ func (r *user) Update(ctx *abstraction.Context, data *model.UserEntityModel) *go
 	return r.CheckTrx(ctx).Model(data).Where("id = ?", data.ID).Updates(data)
 }
 
+// UpdateWithVersion hanya mengupdate jika version di db masih sama lalu menaikkan version,
+// RowsAffected 0 berarti data sudah diubah oleh user lain
+func (r *user) UpdateWithVersion(ctx *abstraction.Context, data *model.UserEntityModel, version int) *gorm.DB {
+	data.Version = version + 1
+	return r.CheckTrx(ctx).Model(data).Where("id = ? AND version = ?", data.ID, version).Updates(data)
+}
+
 func (r *user) FindByRoleIdArr(ctx *abstraction.Context, role_id int, no_paging bool) (data []*model.UserEntityModel, err error) {
 	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
 	order := general.ProcessOrder(ctx)
@@ -109,3 +122,57 @@ func (r *user) FindByRoleIdArr(ctx *abstraction.Context, role_id int, no_paging
 		Error
 	return
 }
+
+// FindByRoleId: tanpa paging/order dari query param, aman dipakai job background yang tidak punya request http
+func (r *user) FindByRoleId(ctx *abstraction.Context, role_id int) (data []*model.UserEntityModel, err error) {
+	err = r.CheckTrx(ctx).
+		Where("role_id = ? AND is_delete = ?", role_id, false).
+		Order("id ASC").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *user) UpdateColumns(ctx *abstraction.Context, id int, data map[string]interface{}) *gorm.DB {
+	return r.CheckTrx(ctx).Model(&model.UserEntityModel{}).Where("id = ?", id).Updates(data)
+}
+
+func (r *user) FindTrash(ctx *abstraction.Context, no_paging bool) (data []*model.UserEntityModel, err error) {
+	limit, offset := general.ProcessLimitOffset(ctx, no_paging)
+	err = r.CheckTrx(ctx).
+		Where("is_delete = ?", true).
+		Order("deleted_at DESC, id DESC").
+		Limit(limit).
+		Offset(offset).
+		Preload("Role").
+		Find(&data).
+		Error
+	return
+}
+
+func (r *user) CountTrash(ctx *abstraction.Context) (data *int, err error) {
+	var count model.UserCountDataModel
+	err = r.CheckTrx(ctx).
+		Table("user").
+		Select("COUNT(*) AS count").
+		Where("is_delete = ?", true).
+		Find(&count).
+		Error
+	data = &count.Count
+	return
+}
+
+func (r *user) FindDeletedById(ctx *abstraction.Context, id int) (*model.UserEntityModel, error) {
+	conn := r.CheckTrx(ctx)
+
+	var data model.UserEntityModel
+	err := conn.
+		Where("id = ? AND is_delete = ?", id, true).
+		Preload("Role").
+		First(&data).
+		Error
+	if err != nil {
+		return nil, err
+	}
+	return &data, nil
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bm_binus/internal/app/sla"
+	"bm_binus/internal/app/trash"
 	"bm_binus/internal/config"
 	"bm_binus/internal/factory"
 	httpbm_binus "bm_binus/internal/http"
@@ -48,6 +50,10 @@ func main() {
 
 	ws.InitCentrifugal(ctx, e, f)
 
+	sla.StartChecker(ctx, f)
+
+	trash.StartPurger(ctx, f)
+
 	go func() {
 		runNgrok := false
 		addr := ""
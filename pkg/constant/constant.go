@@ -12,9 +12,40 @@ const (
 	STATUS_ID_PROSES     = 3
 	STATUS_ID_FINALISASI = 4
 	STATUS_ID_SELESAI    = 5
+	STATUS_ID_DITOLAK    = 6
+	STATUS_ID_DIBATALKAN = 7
+	STATUS_ID_DRAFT      = 8
 
 	BLANK_REQUEST_ID = 1
 
+	REQUEST_RECURRENCE_MAX = 52
+	REQUEST_BULK_MAX       = 100
+
+	SLA_CHECK_INTERVAL_MINUTES = 5
+
+	TRASH_RETENTION_DAYS       = 30
+	TRASH_PURGE_INTERVAL_HOURS = 24
+
+	APPROVAL_DECISION_PENDING  = "pending"
+	APPROVAL_DECISION_APPROVED = "approved"
+	APPROVAL_DECISION_REJECTED = "rejected"
+
+	// AHP_CR_THRESHOLD: batas consistency ratio yang masih dianggap konsisten (Saaty)
+	AHP_CR_THRESHOLD = 0.1
+	// AHP_METHOD_*: cara menghitung bobot AHP, eigenvector memakai power iteration sampai selisih bobot < AHP_EIGEN_TOLERANCE
+	AHP_METHOD_APPROXIMATE  = "approximate"
+	AHP_METHOD_EIGENVECTOR  = "eigenvector"
+	AHP_EIGEN_TOLERANCE     = 1e-10
+	AHP_EIGEN_MAX_ITERATION = 1000
+	// AHP_HINT_LIMIT: jumlah perbandingan paling tidak konsisten yang disarankan untuk direvisi saat CR > 0.1
+	AHP_HINT_LIMIT = 3
+	// AHP_RANKING_CACHE_TTL_MINUTES: umur cache ranking AHP di redis
+	AHP_RANKING_CACHE_TTL_MINUTES = 30
+
+	ASSIGN_STRATEGY_MANUAL       = "manual"
+	ASSIGN_STRATEGY_ROUND_ROBIN  = "round_robin"
+	ASSIGN_STRATEGY_LEAST_LOADED = "least_loaded"
+
 	REDIS_REQUEST_IP_KEYS        = "bmbinus-reset-password:ip:%s"
 	REDIS_REQUEST_MAX_ATTEMPTS   = 10
 	REDIS_REQUEST_IP_EXPIRE      = 240
@@ -23,6 +54,8 @@ const (
 	REDIS_KEY_REFRESH_TOKEN      = "bmbinus-refresh-token:%s"
 	REDIS_MAX_REFRESH_TOKEN      = 30
 	REDIS_KEY_USE_PRIORITY_COUNT = "use_priority_count"
+	REDIS_KEY_ASSIGN_ROUND_ROBIN = "bmbinus-assign-round-robin"
+	REDIS_KEY_AHP_RANKING        = "bmbinus-ahp-ranking:%s"
 
 	PATH_FILE_SAVED    = "../file_saved"
 	PATH_ASSETS_IMAGES = "assets/images"
@@ -32,4 +65,11 @@ const (
 var (
 	BASE_URL    string = ""
 	BASE_URL_UI string = "https://bmbinus.my.id/"
+
+	// ASSET_BOOKED_STATUS_IDS: status request yang sudah disetujui sehingga pesanan asetnya mengurangi stok
+	ASSET_BOOKED_STATUS_IDS = []int{STATUS_ID_PROSES, STATUS_ID_FINALISASI, STATUS_ID_SELESAI}
+
+	// AHP_CRITERIA: kriteria ranking request, AHP_CRITERIA_DEFAULT_SCORES dipakai jika BM belum punya profil aktif
+	AHP_CRITERIA                = []string{"Urgency", "Importance", "Participants", "Complexity"}
+	AHP_CRITERIA_DEFAULT_SCORES = []float64{5, 3, 2, 1}
 )
@@ -212,3 +212,26 @@ func RenameFile(service *drive.Service, fileID, newName string) (*drive.File, er
 
 	return updatedFile, nil
 }
+
+func CopyFile(service *drive.Service, fileID string, newName string, parentId string) (*drive.File, error) {
+	f := &drive.File{
+		Name:    newName,
+		Parents: []string{parentId},
+	}
+	file, err := service.Files.Copy(fileID, f).Do()
+	if err != nil {
+		return nil, fmt.Errorf("unable to copy file with ID %s: %v", fileID, err)
+	}
+
+	permission := &drive.Permission{
+		Role: "reader",
+		Type: "anyone",
+	}
+	_, err = service.Permissions.Create(file.Id, permission).Do()
+	if err != nil {
+		logrus.Println("Could not set permission: " + err.Error())
+		return nil, err
+	}
+
+	return file, nil
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	"github.com/go-redis/redis/v8"
@@ -13,30 +14,150 @@ import (
 
 // --- Helper AHP utilities ---
 
-// CalculateAHP: hitung bobot & CR dari matriks perbandingan pairwise (n x n)
+// AhpResult: hasil lengkap perhitungan AHP dari satu matriks pairwise
+type AhpResult struct {
+	Method     string    `json:"method"`
+	Weights    []float64 `json:"weights"`
+	LambdaMax  float64   `json:"lambda_max"`
+	CI         float64   `json:"ci"`
+	CR         float64   `json:"cr"`
+	Iterations int       `json:"iterations"`
+	Converged  bool      `json:"converged"`
+}
+
+// CalculateAHP: hitung bobot & CR dari matriks perbandingan pairwise (n x n) dengan pendekatan normalisasi kolom
 func CalculateAHP(matrix [][]float64) ([]float64, float64) {
-	n := len(matrix)
+	result := CalculateAHPWithMethod(matrix, constant.AHP_METHOD_APPROXIMATE)
+	return result.Weights, result.CR
+}
+
+// IsValidAhpMethod: method kosong dianggap approximate
+func IsValidAhpMethod(method string) bool {
+	return method == "" || method == constant.AHP_METHOD_APPROXIMATE || method == constant.AHP_METHOD_EIGENVECTOR
+}
+
+// CalculateAHPWithMethod: hitung bobot, lambda max, CI & CR dengan method approximate (rata-rata baris matriks
+// ternormalisasi kolom) atau eigenvector (power iteration sampai konvergen)
+func CalculateAHPWithMethod(matrix [][]float64, method string) AhpResult {
+	return calculateAHP(len(matrix), func(i, j int) float64 { return matrix[i][j] }, method)
+}
+
+// CalculateAHPFromScores sama dengan CalculateAHPWithMethod(BuildPairwiseFromScores(scores), method), tetapi nilai
+// matriks dihitung langsung dari skor saat dibutuhkan sehingga memori tetap O(n) untuk alternatif yang banyak
+func CalculateAHPFromScores(scores []float64, method string) AhpResult {
+	return calculateAHP(len(scores), func(i, j int) float64 { return pairwiseFromScores(scores[i], scores[j]) }, method)
+}
+
+// calculateAHP: at(i, j) mengembalikan nilai baris i kolom j dari matriks pairwise n x n
+func calculateAHP(n int, at func(i, j int) float64, method string) AhpResult {
+	if method == "" {
+		method = constant.AHP_METHOD_APPROXIMATE
+	}
+	result := AhpResult{Method: method, Converged: true}
 	if n == 0 {
-		return nil, 0
+		return result
 	}
-	colSum := make([]float64, n)
 
-	for j := 0; j < n; j++ {
-		for i := 0; i < n; i++ {
-			colSum[j] += matrix[i][j]
-		}
+	if method == constant.AHP_METHOD_EIGENVECTOR {
+		result.Weights, result.LambdaMax, result.Iterations, result.Converged = eigenvectorAHP(n, at, constant.AHP_EIGEN_TOLERANCE, constant.AHP_EIGEN_MAX_ITERATION)
+	} else {
+		result.Weights = approximateAHP(n, at)
+		result.LambdaMax = lambdaMaxAHP(n, at, result.Weights)
+	}
+
+	if n > 1 {
+		result.CI = (result.LambdaMax - float64(n)) / (float64(n) - 1)
+	}
+
+	if ri := RandomIndex(n); ri != 0 {
+		result.CR = result.CI / ri
+	}
+	return result
+}
+
+// RandomIndex: tabel RI Saaty sampai n=15, di atasnya memakai pendekatan Alonso & Lamata
+// (lambda rata-rata matriks acak = 2.7699n - 4.3513) supaya CR untuk matriks besar tidak jadi 0
+func RandomIndex(n int) float64 {
+	RI := map[int]float64{
+		1: 0.00, 2: 0.00, 3: 0.58, 4: 0.90, 5: 1.12,
+		6: 1.24, 7: 1.32, 8: 1.41, 9: 1.45, 10: 1.49,
+		11: 1.51, 12: 1.54, 13: 1.56, 14: 1.57, 15: 1.58,
 	}
+	if val, ok := RI[n]; ok {
+		return val
+	}
+	if n < 1 {
+		return 0
+	}
+	return (1.7699*float64(n) - 4.3513) / float64(n-1)
+}
+
+// NearestSaatyValue: bulatkan nilai ke skala Saaty terdekat (1/9 ... 1 ... 9) dalam skala logaritmik
+func NearestSaatyValue(v float64) float64 {
+	if v <= 0 || math.IsNaN(v) {
+		return 1
+	}
+	v = clipSaaty(v)
+	if v >= 1 {
+		return math.Round(v)
+	}
+	return 1 / math.Round(1/v)
+}
 
-	normalized := make([][]float64, n)
+// InconsistencyHints mencari perbandingan yang paling menyimpang dari bobot hasil perhitungan. Untuk tiap pasangan,
+// rasio a_ij * w_j / w_i bernilai 1 jika penilaian konsisten, makin jauh dari 1 makin tidak konsisten.
+// Nilai saran adalah w_i / w_j yang dibulatkan ke skala Saaty
+func InconsistencyHints(items []string, matrix [][]float64, weights []float64, limit int) []map[string]interface{} {
+	type hint struct {
+		i, j      int
+		deviation float64
+	}
+	var hints []hint
+	n := len(matrix)
 	for i := 0; i < n; i++ {
-		normalized[i] = make([]float64, n)
-		for j := 0; j < n; j++ {
-			// protect division by zero
-			if colSum[j] == 0 {
-				normalized[i][j] = 0
-			} else {
-				normalized[i][j] = matrix[i][j] / colSum[j]
+		for j := i + 1; j < n; j++ {
+			if weights[i] == 0 || weights[j] == 0 || matrix[i][j] <= 0 {
+				continue
 			}
+			ratio := matrix[i][j] * weights[j] / weights[i]
+			deviation := math.Max(ratio, 1/ratio)
+			if deviation-1 < 1e-6 {
+				continue
+			}
+			hints = append(hints, hint{i: i, j: j, deviation: deviation})
+		}
+	}
+	sort.SliceStable(hints, func(a, b int) bool {
+		return hints[a].deviation > hints[b].deviation
+	})
+
+	res := []map[string]interface{}{}
+	for idx, h := range hints {
+		if idx >= limit {
+			break
+		}
+		item1, item2 := fmt.Sprint(h.i), fmt.Sprint(h.j)
+		if h.i < len(items) && h.j < len(items) {
+			item1, item2 = items[h.i], items[h.j]
+		}
+		res = append(res, map[string]interface{}{
+			"item1":     item1,
+			"item2":     item2,
+			"current":   matrix[h.i][h.j],
+			"suggested": NearestSaatyValue(weights[h.i] / weights[h.j]),
+			"deviation": math.Round(h.deviation*10000) / 10000,
+		})
+	}
+	return res
+}
+
+// approximateAHP: bobot = rata-rata tiap baris matriks yang sudah dinormalisasi per kolom
+func approximateAHP(n int, at func(i, j int) float64) []float64 {
+	colSum := make([]float64, n)
+
+	for j := 0; j < n; j++ {
+		for i := 0; i < n; i++ {
+			colSum[j] += at(i, j)
 		}
 	}
 
@@ -44,39 +165,71 @@ func CalculateAHP(matrix [][]float64) ([]float64, float64) {
 	for i := 0; i < n; i++ {
 		sum := 0.0
 		for j := 0; j < n; j++ {
-			sum += normalized[i][j]
+			// protect division by zero
+			if colSum[j] != 0 {
+				sum += at(i, j) / colSum[j]
+			}
 		}
 		weights[i] = sum / float64(n)
 	}
+	return weights
+}
 
-	// lambda_max
+// lambdaMaxAHP: rata-rata (A.w)_i / w_i
+func lambdaMaxAHP(n int, at func(i, j int) float64, weights []float64) float64 {
 	lambdaMax := 0.0
 	for i := 0; i < n; i++ {
 		rowSum := 0.0
 		for j := 0; j < n; j++ {
-			rowSum += matrix[i][j] * weights[j]
+			rowSum += at(i, j) * weights[j]
 		}
 		// protect zero weight
 		if weights[i] != 0 {
 			lambdaMax += rowSum / weights[i]
 		}
 	}
-	lambdaMax /= float64(n)
+	return lambdaMax / float64(n)
+}
 
-	CI := 0.0
-	if n > 1 {
-		CI = (lambdaMax - float64(n)) / (float64(n) - 1)
-	}
+// EigenvectorAHP mencari principal eigenvector dengan power iteration: w(k+1) = A.w(k) dinormalisasi supaya totalnya 1.
+// Karena total w(k) = 1, total A.w(k) adalah estimasi lambda max. Iterasi berhenti jika selisih bobot terbesar
+// di bawah tolerance atau sudah mencapai maxIteration (converged = false)
+func EigenvectorAHP(matrix [][]float64, tolerance float64, maxIteration int) ([]float64, float64, int, bool) {
+	return eigenvectorAHP(len(matrix), func(i, j int) float64 { return matrix[i][j] }, tolerance, maxIteration)
+}
 
-	RI := map[int]float64{
-		1: 0.00, 2: 0.00, 3: 0.58, 4: 0.90, 5: 1.12,
-		6: 1.24, 7: 1.32, 8: 1.41, 9: 1.45, 10: 1.49,
+func eigenvectorAHP(n int, at func(i, j int) float64, tolerance float64, maxIteration int) ([]float64, float64, int, bool) {
+	weights := make([]float64, n)
+	for i := range weights {
+		weights[i] = 1 / float64(n)
 	}
-	CR := 0.0
-	if val, ok := RI[n]; ok && val != 0 {
-		CR = CI / val
+
+	lambdaMax := 0.0
+	for iteration := 1; iteration <= maxIteration; iteration++ {
+		next := make([]float64, n)
+		total := 0.0
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				next[i] += at(i, j) * weights[j]
+			}
+			total += next[i]
+		}
+		if total == 0 {
+			return weights, 0, iteration, false
+		}
+		lambdaMax = total
+
+		diff := 0.0
+		for i := 0; i < n; i++ {
+			next[i] /= total
+			diff = math.Max(diff, math.Abs(next[i]-weights[i]))
+		}
+		weights = next
+		if diff < tolerance {
+			return weights, lambdaMax, iteration, true
+		}
 	}
-	return weights, CR
+	return weights, lambdaMax, maxIteration, false
 }
 
 // clipSaaty: clip ratio ke rentang [1/9, 9]
@@ -104,17 +257,123 @@ func BuildPairwiseFromScores(scores []float64) [][]float64 {
 	for i := 0; i < n; i++ {
 		matrix[i] = make([]float64, n)
 		for j := 0; j < n; j++ {
-			// jika kedua nilai 0 -> set 1
-			if scores[j] == 0 {
-				if scores[i] == 0 {
-					matrix[i][j] = 1
-				} else {
-					matrix[i][j] = clipSaaty(scores[i] / 1e-9)
-				}
-			} else {
-				matrix[i][j] = clipSaaty(scores[i] / scores[j])
+			matrix[i][j] = pairwiseFromScores(scores[i], scores[j])
+		}
+	}
+	return matrix
+}
+
+// pairwiseFromScores: nilai satu sel BuildPairwiseFromScores
+func pairwiseFromScores(si float64, sj float64) float64 {
+	// jika kedua nilai 0 -> set 1
+	if sj == 0 {
+		if si == 0 {
+			return 1
+		}
+		return clipSaaty(si / 1e-9)
+	}
+	return clipSaaty(si / sj)
+}
+
+// AhpComparison: satu penilaian pairwise, Value berarti Item1 sekian kali lebih penting dari Item2
+type AhpComparison struct {
+	Item1 string
+	Item2 string
+	Value float64
+}
+
+// ahpPairKey: kunci pasangan tanpa memperhatikan urutan item
+func ahpPairKey(i, j int) [2]int {
+	if i > j {
+		return [2]int{j, i}
+	}
+	return [2]int{i, j}
+}
+
+// ValidatePairwiseComparisons memeriksa penilaian pairwise terhadap daftar items: nama yang tidak dikenal,
+// perbandingan dengan diri sendiri, nilai di luar skala Saaty 1/9-9, pasangan yang dinilai berbeda (tidak resiprokal)
+// dan pasangan yang belum dinilai. Hasilnya daftar masalah, kosong berarti valid
+func ValidatePairwiseComparisons(items []string, comps []AhpComparison) []map[string]interface{} {
+	var problems []map[string]interface{}
+	addProblem := func(kind string, c AhpComparison, message string) {
+		problems = append(problems, map[string]interface{}{
+			"type":    kind,
+			"item1":   c.Item1,
+			"item2":   c.Item2,
+			"value":   c.Value,
+			"message": message,
+		})
+	}
+
+	index := make(map[string]int)
+	for i, v := range items {
+		index[v] = i
+	}
+
+	judged := make(map[[2]int]float64)
+	for _, c := range comps {
+		i1, ok1 := index[c.Item1]
+		i2, ok2 := index[c.Item2]
+		if !ok1 || !ok2 {
+			addProblem("unknown_item", c, "item tidak terdaftar")
+			continue
+		}
+		if i1 == i2 {
+			addProblem("self_comparison", c, "item tidak bisa dibandingkan dengan dirinya sendiri")
+			continue
+		}
+		if math.IsNaN(c.Value) || math.IsInf(c.Value, 0) || c.Value < 1.0/9.0-1e-9 || c.Value > 9.0+1e-9 {
+			addProblem("out_of_scale", c, "nilai harus berada pada skala Saaty 1/9 sampai 9")
+			continue
+		}
+
+		// simpan nilai dalam arah i<j supaya (A,B) dan (B,A) bisa dibandingkan
+		key := ahpPairKey(i1, i2)
+		value := c.Value
+		if i1 > i2 {
+			value = 1 / c.Value
+		}
+		if prev, ok := judged[key]; ok {
+			if math.Abs(prev-value) > 1e-6 {
+				addProblem("conflict", c, "pasangan ini sudah dinilai dengan nilai yang tidak resiprokal")
 			}
+			continue
+		}
+		judged[key] = value
+	}
+
+	for i := 0; i < len(items); i++ {
+		for j := i + 1; j < len(items); j++ {
+			if _, ok := judged[[2]int{i, j}]; !ok {
+				addProblem("missing_pair", AhpComparison{Item1: items[i], Item2: items[j]}, "pasangan ini belum dinilai")
+			}
+		}
+	}
+	return problems
+}
+
+// BuildPairwiseFromComparisons: bangun matriks pairwise dari penilaian yang sudah lolos ValidatePairwiseComparisons
+func BuildPairwiseFromComparisons(items []string, comps []AhpComparison) [][]float64 {
+	n := len(items)
+	index := make(map[string]int)
+	for i, v := range items {
+		index[v] = i
+	}
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		for j := range matrix[i] {
+			matrix[i][j] = 1
+		}
+	}
+	for _, c := range comps {
+		i1, ok1 := index[c.Item1]
+		i2, ok2 := index[c.Item2]
+		if !ok1 || !ok2 || i1 == i2 || c.Value <= 0 {
+			continue
 		}
+		matrix[i1][i2] = c.Value
+		matrix[i2][i1] = 1 / c.Value
 	}
 	return matrix
 }
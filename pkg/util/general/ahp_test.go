@@ -0,0 +1,23 @@
+package general
+
+import (
+	"bm_binus/pkg/constant"
+	"math"
+	"testing"
+)
+
+func TestCalculateAHPFromScoresMatchesMatrix(t *testing.T) {
+	scores := []float64{0, 1, 2.5, 30, 0.05, 7, 7, 120}
+	for _, method := range []string{constant.AHP_METHOD_APPROXIMATE, constant.AHP_METHOD_EIGENVECTOR} {
+		want := CalculateAHPWithMethod(BuildPairwiseFromScores(scores), method)
+		got := CalculateAHPFromScores(scores, method)
+		if math.Abs(got.LambdaMax-want.LambdaMax) > 1e-9 || math.Abs(got.CR-want.CR) > 1e-9 {
+			t.Fatalf("%s: lambda/cr %v/%v, want %v/%v", method, got.LambdaMax, got.CR, want.LambdaMax, want.CR)
+		}
+		for i := range scores {
+			if math.Abs(got.Weights[i]-want.Weights[i]) > 1e-12 {
+				t.Fatalf("%s: weight[%d] = %v, want %v", method, i, got.Weights[i], want.Weights[i])
+			}
+		}
+	}
+}
@@ -279,6 +279,18 @@ func ProcessWhereParam(ctx *abstraction.Context, searchType string, whereStr str
 		case "event_type":
 			where += " AND (LOWER(name) LIKE @search_name)"
 			whereParam["search_name"] = val
+		case "location":
+			where += " AND (LOWER(name) LIKE @search_name OR LOWER(building) LIKE @search_building)"
+			whereParam["search_name"] = val
+			whereParam["search_building"] = val
+		case "asset":
+			where += " AND (LOWER(name) LIKE @search_name OR LOWER(item_type) LIKE @search_item_type)"
+			whereParam["search_name"] = val
+			whereParam["search_item_type"] = val
+		case "request_template":
+			where += " AND (LOWER(name) LIKE @search_name OR LOWER(event_name) LIKE @search_event_name)"
+			whereParam["search_name"] = val
+			whereParam["search_event_name"] = val
 		case "notification":
 			where += " AND (LOWER(title) LIKE @search_title OR LOWER(message) LIKE @search_message)"
 			whereParam["search_title"] = val
@@ -323,6 +335,21 @@ func ProcessWhereParam(ctx *abstraction.Context, searchType string, whereStr str
 		where += " AND LOWER(event_location) LIKE @event_location"
 		whereParam["event_location"] = val
 	}
+	if ctx.QueryParam("building") != "" {
+		val := "%" + SanitizeString(ctx.QueryParam("building")) + "%"
+		where += " AND LOWER(building) LIKE @building"
+		whereParam["building"] = val
+	}
+	if ctx.QueryParam("item_type") != "" {
+		val := "%" + SanitizeString(ctx.QueryParam("item_type")) + "%"
+		where += " AND LOWER(item_type) LIKE @item_type"
+		whereParam["item_type"] = val
+	}
+	if ctx.QueryParam("location_id") != "" {
+		val, _ := strconv.Atoi(SanitizeStringOfNumber(ctx.QueryParam("location_id")))
+		where += " AND location_id = @location_id"
+		whereParam["location_id"] = val
+	}
 	if ctx.QueryParam("role_id") != "" {
 		val, _ := strconv.Atoi(SanitizeStringOfNumber(ctx.QueryParam("role_id")))
 		where += " AND role_id = @role_id"
@@ -361,6 +388,12 @@ func ProcessWhereParam(ctx *abstraction.Context, searchType string, whereStr str
 	if ctx.QueryParam("is_read") != "" {
 		where += " AND is_read = @" + SanitizeStringOfAlphabet(ctx.QueryParam("is_read"))
 	}
+	if ctx.QueryParam("is_active") != "" {
+		where += " AND is_active = @" + SanitizeStringOfAlphabet(ctx.QueryParam("is_active"))
+	}
+	if ctx.QueryParam("is_overdue") != "" {
+		where += " AND is_overdue = @" + SanitizeStringOfAlphabet(ctx.QueryParam("is_overdue"))
+	}
 	if ctx.QueryParam("created_at") != "" {
 		val := SanitizeStringDateBetween(ctx.QueryParam("created_at"))
 		valDate := strings.Split(val, "_")
@@ -395,9 +428,9 @@ func ProcessWhereParam(ctx *abstraction.Context, searchType string, whereStr str
 		val := SanitizeString(ctx.QueryParam("for"))
 		switch val {
 		case "staf":
-			where += " AND status_id IN (1,2,3,4,5)"
+			where += " AND status_id IN (1,2,3,4,5,6,7,8)"
 		case "bm":
-			where += " AND status_id IN (1,2,3,4,5)"
+			where += " AND status_id IN (1,2,3,4,5,6,7)"
 		case "admin":
 			where += " AND status_id IN (3,4,5)"
 		}
@@ -651,6 +684,23 @@ func FormatNamesFromArray(names []string) string {
 	return strings.Join(names[:n-1], ", ") + ", dan " + names[n-1]
 }
 
+// FormatRupiah memformat nominal ke "Rp 1.250.000"
+func FormatRupiah(amount int64) string {
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	digits := strconv.FormatInt(amount, 10)
+	var parts []string
+	for len(digits) > 3 {
+		parts = append([]string{digits[len(digits)-3:]}, parts...)
+		digits = digits[:len(digits)-3]
+	}
+	parts = append([]string{digits}, parts...)
+	return sign + "Rp " + strings.Join(parts, ".")
+}
+
 type Color struct {
 	Name    string
 	R, G, B int
@@ -0,0 +1,190 @@
+package general
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceRule: subset RRULE RFC 5545 (FREQ, INTERVAL, COUNT, UNTIL, BYDAY)
+type RecurrenceRule struct {
+	Freq     string
+	Interval int
+	Count    int
+	Until    *time.Time
+	ByDay    []time.Weekday
+}
+
+var rruleWeekday = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// ParseRecurrenceRule membaca string seperti "FREQ=WEEKLY;INTERVAL=1;COUNT=10;BYDAY=MO,WE"
+func ParseRecurrenceRule(rule string) (*RecurrenceRule, error) {
+	res := &RecurrenceRule{Interval: 1}
+	rule = strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:")
+	for _, part := range strings.Split(rule, ";") {
+		if strings.TrimSpace(part) == "" {
+			continue
+		}
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid rule part %s", part)
+		}
+		key, val := strings.ToUpper(strings.TrimSpace(kv[0])), strings.TrimSpace(kv[1])
+		switch key {
+		case "FREQ":
+			res.Freq = strings.ToUpper(val)
+			if res.Freq != "DAILY" && res.Freq != "WEEKLY" && res.Freq != "MONTHLY" {
+				return nil, fmt.Errorf("unsupported FREQ %s", val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, errors.New("INTERVAL must be a positive number")
+			}
+			res.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, errors.New("COUNT must be a positive number")
+			}
+			res.Count = n
+		case "UNTIL":
+			until, err := parseRecurrenceDate(val)
+			if err != nil {
+				return nil, errors.New("UNTIL must use YYYYMMDD or YYYY-MM-DD format")
+			}
+			// UNTIL inklusif sampai akhir hari
+			until = until.Add(24*time.Hour - time.Second)
+			res.Until = &until
+		case "BYDAY":
+			for _, d := range strings.Split(val, ",") {
+				wd, ok := rruleWeekday[strings.ToUpper(strings.TrimSpace(d))]
+				if !ok {
+					return nil, fmt.Errorf("invalid BYDAY %s", d)
+				}
+				res.ByDay = append(res.ByDay, wd)
+			}
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", key)
+		}
+	}
+	if res.Freq == "" {
+		return nil, errors.New("FREQ is required")
+	}
+	if res.Count == 0 && res.Until == nil {
+		return nil, errors.New("COUNT or UNTIL is required")
+	}
+	if len(res.ByDay) > 0 && res.Freq != "WEEKLY" {
+		return nil, errors.New("BYDAY is only supported for WEEKLY")
+	}
+	return res, nil
+}
+
+func parseRecurrenceDate(val string) (time.Time, error) {
+	val = strings.TrimSpace(val)
+	if len(val) >= 8 && !strings.Contains(val, "-") {
+		return time.ParseInLocation("20060102", val[:8], Location())
+	}
+	return time.ParseInLocation("2006-01-02", val, Location())
+}
+
+// ParseRecurrenceExdates membaca daftar tanggal pengecualian yang dipisah koma
+func ParseRecurrenceExdates(val string) (map[string]bool, error) {
+	res := map[string]bool{}
+	for _, v := range strings.Split(val, ",") {
+		if strings.TrimSpace(v) == "" {
+			continue
+		}
+		date, err := parseRecurrenceDate(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exdate %s", v)
+		}
+		res[date.Format("2006-01-02")] = true
+	}
+	return res, nil
+}
+
+// Expand menghasilkan tanggal mulai tiap kejadian (termasuk start), melewati exdates,
+// error jika jumlah kejadian melebihi max
+func (r *RecurrenceRule) Expand(start time.Time, exdates map[string]bool, max int) ([]time.Time, error) {
+	var (
+		res      []time.Time
+		emitted  int
+		exceeded bool
+	)
+	// accept false berarti ekspansi selesai, exceeded menandai berhenti karena melebihi max
+	accept := func(t time.Time) bool {
+		if r.Until != nil && t.After(*r.Until) {
+			return false
+		}
+		if r.Count > 0 && emitted >= r.Count {
+			return false
+		}
+		emitted++
+		if !exdates[t.Format("2006-01-02")] {
+			if len(res) >= max {
+				exceeded = true
+				return false
+			}
+			res = append(res, t)
+		}
+		return true
+	}
+	finish := func() ([]time.Time, error) {
+		if exceeded {
+			return nil, fmt.Errorf("recurrence exceeds %d occurrences", max)
+		}
+		return res, nil
+	}
+
+	for i := 0; ; i++ {
+		switch r.Freq {
+		case "DAILY":
+			if !accept(start.AddDate(0, 0, i*r.Interval)) {
+				return finish()
+			}
+		case "MONTHLY":
+			t := start.AddDate(0, i*r.Interval, 0)
+			// tanggal yang tidak ada di bulan tersebut (mis. 31) dilewati sesuai RFC 5545
+			if t.Day() != start.Day() {
+				continue
+			}
+			if !accept(t) {
+				return finish()
+			}
+		case "WEEKLY":
+			if len(r.ByDay) == 0 {
+				if !accept(start.AddDate(0, 0, i*7*r.Interval)) {
+					return finish()
+				}
+				continue
+			}
+			weekStart := start.AddDate(0, 0, -((int(start.Weekday())+6)%7)+i*7*r.Interval)
+			var days []time.Time
+			for _, wd := range r.ByDay {
+				t := weekStart.AddDate(0, 0, (int(wd)+6)%7)
+				if t.Before(start) {
+					continue
+				}
+				days = append(days, t)
+			}
+			sort.Slice(days, func(a, b int) bool { return days[a].Before(days[b]) })
+			for _, t := range days {
+				if !accept(t) {
+					return finish()
+				}
+			}
+		}
+	}
+}
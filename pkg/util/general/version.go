@@ -0,0 +1,23 @@
+package general
+
+import (
+	"bm_binus/internal/abstraction"
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ProcessVersion mengambil version yang diharapkan client dari header If-Match ("3" atau W/"3"),
+// jika header kosong memakai field version di body. nil berarti update tanpa pengecekan version
+func ProcessVersion(ctx *abstraction.Context, version *int) (*int, error) {
+	ifMatch := strings.TrimSpace(ctx.Request().Header.Get("If-Match"))
+	if ifMatch == "" || ifMatch == "*" {
+		return version, nil
+	}
+	ifMatch = strings.Trim(strings.TrimPrefix(ifMatch, "W/"), `"`)
+	res, err := strconv.Atoi(ifMatch)
+	if err != nil || res < 0 {
+		return nil, errors.New("If-Match must contain the version number")
+	}
+	return &res, nil
+}
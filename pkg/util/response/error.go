@@ -1,6 +1,7 @@
 package response
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -21,6 +22,34 @@ func ErrorBuilder(code int, err error, msg string) *MetaError {
 	}
 }
 
+func ErrorBuilderWithData(code int, err error, msg string, data map[string]interface{}) *MetaError {
+	errData := map[string]interface{}{
+		"error":   err.Error(),
+		"message": msg,
+	}
+	for k, v := range data {
+		errData[k] = v
+	}
+	return &MetaError{
+		Success:      false,
+		Data:         errData,
+		Code:         code,
+		errorMessage: err,
+	}
+}
+
+// ErrorVersionConflict: 409 saat version dari client sudah usang, current berisi data terbaru (nil jika tidak diketahui)
+func ErrorVersionConflict(current map[string]interface{}) *MetaError {
+	return ErrorBuilderWithData(
+		http.StatusConflict,
+		errors.New("version_conflict"),
+		"Data sudah diubah oleh pengguna lain, silakan muat ulang data",
+		map[string]interface{}{
+			"current": current,
+		},
+	)
+}
+
 func ErrorResponse(err error) *MetaError {
 	re, ok := err.(*MetaError)
 	if ok {
@@ -34,6 +63,16 @@ func (e *MetaError) Error() string {
 	return fmt.Sprintf("error code %d", e.Code)
 }
 
+// Message mengembalikan pesan error yang dikirim ke client
+func (e *MetaError) Message() string {
+	if data, ok := e.Data.(map[string]interface{}); ok {
+		if msg, ok := data["message"].(string); ok {
+			return msg
+		}
+	}
+	return e.Error()
+}
+
 func (e *MetaError) ParseToError() error {
 	return e
 }